@@ -15,18 +15,23 @@
 package archivecmd
 
 import (
+	"bytes"
 	"context"
 	"flag"
 	"fmt"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/gohugoio/hugoreleaser-plugins-api/archiveplugin"
 	"github.com/gohugoio/hugoreleaser/cmd/corecmd"
 	"github.com/gohugoio/hugoreleaser/internal/archives"
 	"github.com/gohugoio/hugoreleaser/internal/config"
+	"github.com/gohugoio/hugoreleaser/internal/licenses"
 	"github.com/gohugoio/hugoreleaser/internal/plugins"
+	"github.com/gohugoio/hugoreleaser/internal/sbom"
 
 	"github.com/bep/helpers/filehelpers"
 	"github.com/bep/logg"
@@ -112,6 +117,14 @@ func (b *Archivist) Exec(ctx context.Context, args []string) error {
 		return err
 	}
 
+	var licensesOpts licenses.Options
+	if !b.core.Try && b.needsLicenses() {
+		var err error
+		if licensesOpts, err = b.licensesOptions(ctx); err != nil {
+			return err
+		}
+	}
+
 	r, _ := b.core.Workforce.Start(ctx)
 
 	archiveDistDir := filepath.Join(
@@ -179,6 +192,26 @@ func (b *Archivist) Exec(ctx context.Context, args []string) error {
 					Mode:          binFi.Mode(),
 				})
 
+				for _, format := range archiveSettings.SBOM.Formats {
+					sbomFilename := outFilename + sbom.Extension(format)
+					if err := b.writeSBOM(format, sbomFilename, arch, binaryFilename); err != nil {
+						return err
+					}
+					buildRequest.Files = append(buildRequest.Files, archiveplugin.ArchiveFile{
+						SourcePathAbs: sbomFilename,
+						TargetPath:    path.Join(archiveSettings.BinaryDir, arch.BuildSettings.Binary+sbom.Extension(format)),
+						Mode:          0o644,
+					})
+				}
+
+				if archiveSettings.ThirdPartyLicenses.Format != "" {
+					files, err := b.writeLicenses(archiveSettings.ThirdPartyLicenses, outFilename+".licenses", binaryFilename, licensesOpts)
+					if err != nil {
+						return err
+					}
+					buildRequest.Files = append(buildRequest.Files, files...)
+				}
+
 				for _, extraFile := range archiveSettings.ExtraFiles {
 					buildRequest.Files = append(buildRequest.Files, archiveplugin.ArchiveFile{
 						SourcePathAbs: filepath.Join(b.core.ProjectDir, extraFile.SourcePath),
@@ -216,3 +249,104 @@ func (b *Archivist) Exec(ctx context.Context, args []string) error {
 
 	return r.Wait()
 }
+
+func (b *Archivist) writeSBOM(format, filename string, arch config.BuildArch, binaryFilename string) error {
+	content, err := sbom.Generate(format, sbom.Options{
+		Project: b.core.Config.Project,
+		Tag:     b.core.Tag,
+		Binaries: []sbom.Binary{
+			{
+				Name:     arch.BuildSettings.Binary,
+				Goos:     arch.Os.Goos,
+				Goarch:   arch.Goarch,
+				Filename: binaryFilename,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %v", commandName, err)
+	}
+	return os.WriteFile(filename, content, 0o644)
+}
+
+func (b *Archivist) needsLicenses() bool {
+	for _, archive := range b.core.Config.Archives {
+		if archive.ArchiveSettings.ThirdPartyLicenses.Format != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// licensesOptions returns the options needed to find the license files, see licenses.Collect.
+func (b *Archivist) licensesOptions(ctx context.Context) (licenses.Options, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, b.core.Config.GoSettings.GoExe, "env", "GOMODCACHE", "GOROOT")
+	cmd.Dir = b.core.ProjectDir
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return licenses.Options{}, fmt.Errorf("%s: failed to get Go env: %v: %s", commandName, err, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 2 {
+		return licenses.Options{}, fmt.Errorf("%s: unexpected output from go env: %q", commandName, out)
+	}
+	return licenses.Options{
+		ModCacheDir: strings.TrimSpace(lines[0]),
+		GoRoot:      strings.TrimSpace(lines[1]),
+		ProjectDir:  b.core.ProjectDir,
+	}, nil
+}
+
+// writeLicenses collects the license files for the binary into dir and returns the files to add to the archive.
+func (b *Archivist) writeLicenses(settings config.ThirdPartyLicensesSettings, dir, binaryFilename string, opts licenses.Options) ([]archiveplugin.ArchiveFile, error) {
+	opts.Ignore = settings.Ignore
+	modules, err := licenses.Collect(binaryFilename, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", commandName, err)
+	}
+	if err := licenses.Check(modules, settings.Deny); err != nil {
+		return nil, fmt.Errorf("%s: %s: %v", commandName, binaryFilename, err)
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	var files []archiveplugin.ArchiveFile
+
+	switch settings.Format {
+	case config.ThirdPartyLicensesFormatDir:
+		names, err := licenses.WriteDir(dir, modules)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			files = append(files, archiveplugin.ArchiveFile{
+				SourcePathAbs: filepath.Join(dir, filepath.FromSlash(name)),
+				TargetPath:    path.Join(settings.TargetPath, name),
+				Mode:          0o644,
+			})
+		}
+	case config.ThirdPartyLicensesFormatFile:
+		var buf bytes.Buffer
+		if err := licenses.WriteNotice(&buf, modules); err != nil {
+			return nil, err
+		}
+		filename := filepath.Join(dir, path.Base(settings.TargetPath))
+		if err := os.WriteFile(filename, buf.Bytes(), 0o644); err != nil {
+			return nil, err
+		}
+		files = append(files, archiveplugin.ArchiveFile{
+			SourcePathAbs: filename,
+			TargetPath:    settings.TargetPath,
+			Mode:          0o644,
+		})
+	}
+
+	return files, nil
+}
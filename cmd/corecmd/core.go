@@ -36,6 +36,7 @@ import (
 	"github.com/gohugoio/hugoreleaser/internal/common/errorsh"
 	"github.com/gohugoio/hugoreleaser/internal/common/logging"
 	"github.com/gohugoio/hugoreleaser/internal/common/matchers"
+	"github.com/gohugoio/hugoreleaser/internal/common/semver"
 	"github.com/gohugoio/hugoreleaser/internal/common/templ"
 	"github.com/gohugoio/hugoreleaser/internal/config"
 	"github.com/gohugoio/hugoreleaser/plugins/model"
@@ -99,10 +100,20 @@ type Core struct {
 	// Trial run, no builds or releases.
 	Try bool
 
+	// Derive a snapshot Tag from Git, e.g. for nightly builds.
+	Snapshot bool
+
+	// Allow release and publish of a snapshot using the real release clients.
+	AllowSnapshotRelease bool
+
 	// The Git tag to use for the release.
-	// This tag will eventually be created at release time if it does not exist.
+	// If it does not exist, it is created at release time, either implicitly by
+	// the release target (e.g. GitHub) or by the release command if release_settings.git_tag.create is set.
 	Tag string
 
+	// The parsed Tag, zero if allow_non_semver is set and Tag is not a semantic version.
+	Version semver.Version
+
 	// Paths to build/release.
 	Paths                 stringFlags
 	PathsBuildsCompiled   matchers.Matcher
@@ -147,6 +158,15 @@ func (c *Core) Exec(context.Context, []string) error {
 	return flag.ErrHelp
 }
 
+// CheckSnapshotRelease returns an error if this is a snapshot that is not allowed
+// to be released or published using the real release clients.
+func (c *Core) CheckSnapshotRelease(commandName string) error {
+	if c.Snapshot && !c.Try && !c.AllowSnapshotRelease {
+		return fmt.Errorf("%s: refusing to %s snapshot %q, set -allow-snapshot-release or -try", commandName, commandName, c.Tag)
+	}
+	return nil
+}
+
 // RegisterFlags registers the flag fields into the provided flag.FlagSet. This
 // helper function allows subcommands to register the root flags into their
 // flagsets, creating "global" flags that can be passed after any subcommand at
@@ -163,6 +183,8 @@ func (c *Core) RegisterFlags(fs *flag.FlagSet) {
 	fs.DurationVar(&c.Timeout, "timeout", 55*time.Minute, "Global timeout.")
 	fs.BoolVar(&c.Quiet, "quiet", false, "Don't output anything to stdout.")
 	fs.BoolVar(&c.Try, "try", false, "Trial run, no builds, archives or releases.")
+	fs.BoolVar(&c.Snapshot, "snapshot", false, "Derive the tag from Git (e.g. v0.121.0-next+abc1234) instead of -tag, e.g. for nightly builds.")
+	fs.BoolVar(&c.AllowSnapshotRelease, "allow-snapshot-release", false, "Allow release and publish of a -snapshot.")
 }
 
 // PreInit is called before the flags are parsed.
@@ -292,7 +314,8 @@ func (c *Core) Init() error {
 		},
 	)
 
-	c.InfoLog = l.WithLevel(logg.LevelInfo).WithField("cmd", "init")
+	initLog := l.WithLevel(logg.LevelInfo).WithField("cmd", "init")
+	c.InfoLog = initLog
 
 	if !filepath.IsAbs(c.DistDir) {
 		c.DistDir = filepath.Join(c.ProjectDir, c.DistDir)
@@ -302,11 +325,9 @@ func (c *Core) Init() error {
 		}
 	}
 
-	fields := logg.Fields{
-		{Name: "tag", Value: c.Tag},
-		{Name: "dist", Value: c.DistDir},
+	if c.Snapshot && c.Tag != "" {
+		return fmt.Errorf("flags -snapshot and -tag cannot both be set")
 	}
-	c.InfoLog.WithFields(fields).Log(logg.String("Prepare using"))
 
 	logHandler = multi.New(
 		// Replace the Dist dir (usually long path) in the log messages with a shorter version.
@@ -324,7 +345,7 @@ func (c *Core) Init() error {
 	c.WarnLog = l.WithLevel(logg.LevelWarn).WithField("cmd", "core")
 	c.ErrorLog = l.WithLevel(logg.LevelError).WithField("cmd", "core")
 
-	if c.Tag == "" {
+	if c.Tag == "" && !c.Snapshot {
 		return fmt.Errorf("flag -tag is required")
 	}
 
@@ -364,6 +385,26 @@ func (c *Core) Init() error {
 		return fmt.Errorf("%s %q: %w", msg, c.ConfigFile, err)
 	}
 
+	if c.Snapshot {
+		// The snapshot tag needs the tag_pattern from the config.
+		if c.Tag, err = snapshotTag(c.ProjectDir, c.Config.ReleaseSettings.ReleaseNotesSettings.TagPattern); err != nil {
+			return fmt.Errorf("flag -snapshot: %w", err)
+		}
+	}
+
+	fields := logg.Fields{
+		{Name: "tag", Value: c.Tag},
+		{Name: "dist", Value: c.DistDir},
+	}
+	if c.Snapshot {
+		fields = append(fields, logg.Field{Name: "snapshot", Value: true})
+	}
+	initLog.WithFields(fields).Log(logg.String("Prepare using"))
+
+	if c.Version, err = semver.Parse(c.Tag); err != nil && !c.Config.AllowNonSemver {
+		return fmt.Errorf("flag -tag: %w; set allow_non_semver to allow this", err)
+	}
+
 	// Precompile the common navigation for all archives.
 	for i, archive := range c.Config.Archives {
 		archiveSettings := archive.ArchiveSettings
@@ -376,7 +417,13 @@ func (c *Core) Init() error {
 				Goos:    arch.Os.Goos,
 				Goarch:  arch.Goarch,
 			}
-			name, err := templ.Sprintt(archive.ArchiveSettings.NameTemplate, buildInfo)
+			name, err := templ.Sprintt(archive.ArchiveSettings.NameTemplate, struct {
+				model.BuildInfo
+				semver.Version
+			}{
+				BuildInfo: buildInfo,
+				Version:   c.Version,
+			})
 			if err != nil {
 				return fmt.Errorf("error compiling archive name template: %w", err)
 			}
@@ -415,13 +462,8 @@ func (c *Core) Init() error {
 
 	// Precompile publisher -> release mappings.
 	for i := range c.Config.Publishers {
-		pub := &c.Config.Publishers[i]
-		for j := range c.Config.Releases {
-			release := &c.Config.Releases[j]
-			// If no release paths specified, match all releases.
-			if pub.ReleasePathsCompiled == nil || pub.ReleasePathsCompiled.Match(release.Path) {
-				pub.ReleasesCompiled = append(pub.ReleasesCompiled, release)
-			}
+		if err := c.Config.Publishers[i].CompileReleases(c.Config.Releases); err != nil {
+			return err
 		}
 	}
 
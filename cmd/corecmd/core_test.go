@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugoreleaser/internal/common/gith"
 	"github.com/gohugoio/hugoreleaser/internal/common/matchers"
 )
 
@@ -90,3 +91,56 @@ func TestCompilePaths(t *testing.T) {
 	c.Assert((&Core{Paths: []string{"/**"}}).compilePaths(), qt.Not(qt.IsNil))
 
 }
+
+func TestSnapshotTag(t *testing.T) {
+	c := qt.New(t)
+
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		out, err := gith.GitLine(dir, append([]string{"-c", "user.name=hugoreleaser", "-c", "user.email=hugoreleaser@example.org"}, args...)...)
+		c.Assert(err, qt.IsNil)
+		return out
+	}
+
+	git("init", "-q")
+	git("commit", "-q", "--allow-empty", "-m", "First")
+	sha := git("rev-parse", "--short", "HEAD")
+
+	tag, err := snapshotTag(dir, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag, qt.Equals, "v0.1.0-next+"+sha)
+
+	for _, test := range []struct {
+		tag    string
+		expect string
+	}{
+		{"v0.120.2", "v0.121.0-next+"},
+		{"v0.121.0-rc1", "v0.121.0-next+"},
+		{"1.2.3", "1.3.0-next+"},
+		{"tools/v1.2.3", "tools/v1.3.0-next+"},
+	} {
+		git("commit", "-q", "--allow-empty", "-m", test.tag)
+		git("tag", test.tag)
+		sha := git("rev-parse", "--short", "HEAD")
+		tag, err := snapshotTag(dir, "")
+		c.Assert(err, qt.IsNil)
+		c.Assert(tag, qt.Equals, test.expect+sha)
+	}
+
+	// Tags that are not semantic versions are skipped.
+	git("commit", "-q", "--allow-empty", "-m", "nightly")
+	git("tag", "nightly")
+	git("tag", "v2-beta")
+	sha = git("rev-parse", "--short", "HEAD")
+	tag, err = snapshotTag(dir, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag, qt.Equals, "tools/v1.3.0-next+"+sha)
+
+	// Only tags matching the tag pattern are considered.
+	tag, err = snapshotTag(dir, "v[0-9]*")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag, qt.Equals, "v0.121.0-next+"+sha)
+	tag, err = snapshotTag(dir, "foo/v[0-9]*")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag, qt.Equals, "v0.1.0-next+"+sha)
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package corecmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gohugoio/hugoreleaser/internal/common/gith"
+	"github.com/gohugoio/hugoreleaser/internal/common/semver"
+)
+
+// snapshotTag derives a snapshot tag, e.g. v0.121.0-next+abc1234, from the
+// latest semver tag reachable from HEAD in the Git repository in dir.
+// If set, only tags matching tagPattern (see git describe --match) are considered.
+// The next minor version is used, or the same version if the latest tag is a prerelease.
+// Without any tags, the snapshot tag is v0.1.0-next+<shortsha>.
+func snapshotTag(dir, tagPattern string) (string, error) {
+	shortSHA, err := gith.GitLine(dir, "rev-parse", "--short", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	prefix := "v"
+	next := semver.Version{Minor: 1}
+
+	if latest, v, found := latestSemverTag(dir, tagPattern); found {
+		// Keep any path prefix and v prefix, e.g. tools/v.
+		name := latest[strings.LastIndex(latest, "/")+1:]
+		prefix = latest[:len(latest)-len(name)]
+		if strings.HasPrefix(name, "v") {
+			prefix += "v"
+		}
+		next = semver.Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+		if !v.IsPrerelease() {
+			next.Minor++
+			next.Patch = 0
+		}
+	}
+
+	next.Prerelease = "next"
+	next.Metadata = shortSHA
+
+	return prefix + next.String(), nil
+}
+
+// latestSemverTag returns the latest tag reachable from HEAD matching tagPattern, if set,
+// that is a valid semantic version. Other tags, e.g. nightly, are skipped.
+func latestSemverTag(dir, tagPattern string) (string, semver.Version, bool) {
+	args := []string{"describe", "--tags", "--abbrev=0"}
+	if tagPattern != "" {
+		args = append(args, "--match", tagPattern)
+	}
+	for {
+		// This fails if there are no (more) tags.
+		tag, err := gith.GitLine(dir, args...)
+		if err != nil {
+			return "", semver.Version{}, false
+		}
+		if v, err := semver.Parse(tag); err == nil {
+			return tag, v, true
+		}
+		args = append(args, "--exclude", tag)
+	}
+}
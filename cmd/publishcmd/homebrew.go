@@ -0,0 +1,128 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publishcmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gohugoio/hugoreleaser/internal/common/matchers"
+	"github.com/gohugoio/hugoreleaser/internal/config"
+	"github.com/gohugoio/hugoreleaser/internal/releases"
+)
+
+// HomebrewPlatform holds the archives for one OS.
+type HomebrewPlatform struct {
+	Goos  string
+	Block string // The Homebrew block for this OS, e.g. on_macos.
+
+	Arm       *HomebrewArchive // arm64
+	Intel     *HomebrewArchive // amd64
+	Universal *HomebrewArchive // All architectures, e.g. a macOS universal binary.
+}
+
+// HomebrewArchive is an archive referenced from a Homebrew formula or cask.
+type HomebrewArchive struct {
+	Name   string
+	URL    string
+	SHA256 string
+}
+
+// isHomebrewArchive reports whether the archive filename is in a format Homebrew can unpack.
+func isHomebrewArchive(name string) bool {
+	return strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz") || strings.HasSuffix(name, ".zip")
+}
+
+// isHomebrewPkgArchive reports whether the archive filename is a macOS installer package.
+func isHomebrewPkgArchive(name string) bool {
+	return strings.HasSuffix(name, ".pkg")
+}
+
+// findHomebrewArchives finds the archives for goos matching the archive paths pattern and accept.
+// It returns nil if none is found.
+func (p *Publisher) findHomebrewArchives(
+	release *config.Release,
+	archivePathsMatcher matchers.Matcher,
+	goos string,
+	accept func(name string) bool,
+) (*HomebrewPlatform, error) {
+	releaseSettings := release.ReleaseSettings
+	platform := &HomebrewPlatform{Goos: goos}
+	var found bool
+
+	for _, archPath := range release.ArchsCompiled {
+		if archPath.Arch.Os == nil || archPath.Arch.Os.Goos != goos {
+			continue
+		}
+		if archivePathsMatcher != nil && !archivePathsMatcher.Match(archPath.Path) {
+			continue
+		}
+		if !accept(archPath.Name) {
+			continue
+		}
+
+		var target **HomebrewArchive
+		switch archPath.Arch.Goarch {
+		case "arm64":
+			target = &platform.Arm
+		case "amd64":
+			target = &platform.Intel
+		case "universal":
+			target = &platform.Universal
+		default:
+			continue
+		}
+		if *target != nil {
+			return nil, fmt.Errorf("%s: found more than one %s/%s archive (%s and %s), use the publisher's paths to select one", commandName, goos, archPath.Arch.Goarch, (*target).Name, archPath.Name)
+		}
+
+		checksum := archPath.SHA256
+		if checksum == "" {
+			var err error
+			checksum, err = releases.SHA256File(filepath.Join(
+				p.core.DistDir,
+				p.core.Config.Project,
+				p.core.Tag,
+				p.core.DistRootArchives,
+				filepath.FromSlash(archPath.Path),
+				archPath.Name,
+			))
+			if err != nil {
+				return nil, fmt.Errorf("failed to calculate SHA256 for %s: %w", archPath.Name, err)
+			}
+		}
+
+		url, err := releaseSettings.DownloadURL(p.core.Config.Project, p.core.Tag, p.core.Version, archPath.Name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", commandName, err)
+		}
+
+		*target = &HomebrewArchive{
+			Name:   archPath.Name,
+			URL:    url,
+			SHA256: checksum,
+		}
+		found = true
+	}
+
+	if !found {
+		return nil, nil
+	}
+	if platform.Universal != nil && (platform.Arm != nil || platform.Intel != nil) {
+		return nil, fmt.Errorf("%s: found both universal and architecture specific %s archives, use the publisher's paths to select one", commandName, goos)
+	}
+	return platform, nil
+}
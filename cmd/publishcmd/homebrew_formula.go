@@ -0,0 +1,277 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publishcmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bep/logg"
+	"github.com/gohugoio/hugoreleaser-plugins-api/model"
+	"github.com/gohugoio/hugoreleaser/internal/common/templ"
+	"github.com/gohugoio/hugoreleaser/internal/config"
+	"github.com/gohugoio/hugoreleaser/internal/releases"
+	"github.com/gohugoio/hugoreleaser/staticfiles"
+)
+
+// HomebrewFormulaSettings holds the custom settings for homebrew_formula publisher.
+type HomebrewFormulaSettings struct {
+	Name             string `mapstructure:"name"` // The formula name, defaults to the project name.
+	Desc             string `mapstructure:"desc"`
+	Homepage         string `mapstructure:"homepage"`
+	License          string `mapstructure:"license"`
+	Binary           string `mapstructure:"binary"` // Defaults to build_settings.binary.
+	TapOwner         string `mapstructure:"tap_owner"`
+	TapRepository    string `mapstructure:"tap_repository"`
+	FormulaPath      string `mapstructure:"formula_path"`
+	TemplateFilename string `mapstructure:"template_filename"`
+
+	// Templates for the body of the install, caveats and test blocks.
+	InstallTemplate string `mapstructure:"install_template"`
+	CaveatsTemplate string `mapstructure:"caveats_template"`
+	TestTemplate    string `mapstructure:"test_template"`
+
+	TapPullRequestSettings
+}
+
+// HomebrewFormulaContext holds data for the Homebrew formula template.
+type HomebrewFormulaContext struct {
+	Name      string // Formula name, e.g. "hugo"
+	ClassName string // Formula class name, e.g. "Hugo"
+	Version   string
+	Desc      string
+	Homepage  string
+	License   string
+	Binary    string
+
+	// The archives for macOS and Linux, in that order.
+	Platforms []HomebrewPlatform
+
+	// The expanded install, caveats and test templates.
+	Install string
+	Caveats string
+	Test    string
+
+	// The semantic version parts of the tag.
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	Metadata   string
+}
+
+// homebrewOSBlocks maps the GOOS values supported by Homebrew to their blocks.
+var homebrewOSBlocks = []struct{ goos, block string }{
+	{"darwin", "on_macos"},
+	{"linux", "on_linux"},
+}
+
+func (p *Publisher) updateHomebrewFormula(
+	ctx context.Context,
+	logCtx logg.LevelLogger,
+	client releases.PublishClient,
+	pub *config.Publisher,
+	release *config.Release,
+) error {
+	logCtx = logCtx.WithField("action", "homebrew_formula")
+	logCtx.Log(logg.String("Updating Homebrew formula"))
+
+	releaseSettings := release.ReleaseSettings
+
+	settings, err := model.FromMap[any, HomebrewFormulaSettings](pub.CustomSettings)
+	if err != nil {
+		return fmt.Errorf("failed to parse homebrew_formula settings: %w", err)
+	}
+
+	// Apply defaults.
+	if settings.TapOwner == "" {
+		settings.TapOwner = releaseSettings.RepositoryOwner
+	}
+	if settings.TapRepository == "" {
+		settings.TapRepository = "homebrew-tap"
+	}
+	if settings.Name == "" {
+		settings.Name = p.core.Config.Project
+	}
+	if settings.FormulaPath == "" {
+		settings.FormulaPath = fmt.Sprintf("Formula/%s.rb", settings.Name)
+	}
+	if settings.InstallTemplate == "" {
+		settings.InstallTemplate = `bin.install "{{ .Binary }}"`
+	}
+	if settings.Homepage == "" {
+		// Validated in config for the other release types.
+		settings.Homepage = releaseSettings.GitHub.RepositoryURL(releaseSettings.RepositoryOwner, releaseSettings.Repository)
+	}
+
+	formulaCtx := HomebrewFormulaContext{
+		Name:       settings.Name,
+		ClassName:  homebrewClassName(settings.Name),
+		Version:    strings.TrimPrefix(p.core.Tag, "v"),
+		Desc:       settings.Desc,
+		Homepage:   settings.Homepage,
+		License:    settings.License,
+		Binary:     settings.Binary,
+		Major:      p.core.Version.Major,
+		Minor:      p.core.Version.Minor,
+		Patch:      p.core.Version.Patch,
+		Prerelease: p.core.Version.Prerelease,
+		Metadata:   p.core.Version.Metadata,
+	}
+
+	for _, b := range homebrewOSBlocks {
+		platform, err := p.findHomebrewArchives(release, pub.ArchivePathsCompiled, b.goos, isHomebrewArchive)
+		if err != nil {
+			return err
+		}
+		if platform == nil {
+			continue
+		}
+		platform.Block = b.block
+		formulaCtx.Platforms = append(formulaCtx.Platforms, *platform)
+		for _, a := range []*HomebrewArchive{platform.Universal, platform.Arm, platform.Intel} {
+			if a != nil {
+				logCtx.WithField("archive", a.Name).Log(logg.String("Found archive"))
+			}
+		}
+	}
+	if len(formulaCtx.Platforms) == 0 {
+		return fmt.Errorf("%s: homebrew_formula: no .tar.gz or .zip archives found for darwin or linux", commandName)
+	}
+
+	if formulaCtx.Binary == "" {
+		for _, archPath := range release.ArchsCompiled {
+			if archPath.Arch.BuildSettings.Binary != "" {
+				formulaCtx.Binary = archPath.Arch.BuildSettings.Binary
+				break
+			}
+		}
+	}
+
+	for _, t := range []struct {
+		what   string
+		templ  string
+		target *string
+	}{
+		{"install_template", settings.InstallTemplate, &formulaCtx.Install},
+		{"caveats_template", settings.CaveatsTemplate, &formulaCtx.Caveats},
+		{"test_template", settings.TestTemplate, &formulaCtx.Test},
+	} {
+		if t.templ == "" {
+			continue
+		}
+		s, err := templ.Sprintt(t.templ, formulaCtx)
+		if err != nil {
+			return fmt.Errorf("%s: homebrew_formula: failed to expand %s: %v", commandName, t.what, err)
+		}
+		*t.target = strings.TrimSpace(s)
+	}
+
+	// Render formula template.
+	var formulaContent bytes.Buffer
+	var tmpl *template.Template
+
+	if settings.TemplateFilename != "" {
+		templatePath := settings.TemplateFilename
+		if !filepath.IsAbs(templatePath) {
+			templatePath = filepath.Join(p.core.ProjectDir, templatePath)
+		}
+		b, err := os.ReadFile(templatePath)
+		if err != nil {
+			return fmt.Errorf("failed to read custom formula template: %v", err)
+		}
+		tmpl, err = templ.Parse(string(b))
+		if err != nil {
+			return fmt.Errorf("failed to parse custom formula template: %v", err)
+		}
+	} else {
+		tmpl = staticfiles.HomebrewFormulaTemplate
+	}
+
+	if err := tmpl.Execute(&formulaContent, formulaCtx); err != nil {
+		return fmt.Errorf("failed to execute formula template: %v", err)
+	}
+
+	commitMessage := fmt.Sprintf("Update %s to %s", settings.Name, p.core.Tag)
+
+	logCtx = logCtx.WithFields(logg.Fields{
+		{Name: "tap", Value: fmt.Sprintf("%s/%s", settings.TapOwner, settings.TapRepository)},
+		{Name: "path", Value: settings.FormulaPath},
+	})
+
+	if settings.PullRequest {
+		return p.updateTapInPullRequest(ctx, logCtx, client, settings.TapPullRequestSettings, releases.PullRequest{
+			Owner:   settings.TapOwner,
+			Repo:    settings.TapRepository,
+			Path:    settings.FormulaPath,
+			Message: commitMessage,
+			Content: formulaContent.Bytes(),
+		}, settings.Name, formulaCtx)
+	}
+
+	logCtx.Log(logg.String("Committing formula update"))
+
+	if p.core.Try {
+		logCtx.Log(logg.String("Trial run - skipping commit"))
+		return nil
+	}
+
+	sha, err := client.UpdateFileInRepo(
+		ctx,
+		settings.TapOwner,
+		settings.TapRepository,
+		settings.FormulaPath,
+		commitMessage,
+		formulaContent.Bytes(),
+	)
+	if err != nil {
+		return err
+	}
+
+	logCtx.WithField("commit", sha).Log(logg.String("Formula updated successfully"))
+	return nil
+}
+
+var (
+	homebrewClassNameAtRe   = regexp.MustCompile(`(.)@(\d)`)
+	homebrewClassNameWordRe = regexp.MustCompile(`[-_. \t\r\n\f\v]([a-zA-Z0-9])`)
+)
+
+// homebrewClassName returns the Ruby class name Homebrew expects for the formula name,
+// e.g. "Hugo" for "hugo", "HugoExtended" for "hugo-extended" and "OpensslAT3" for "openssl@3".
+// This is a port of Formulary.class_s in Homebrew.
+func homebrewClassName(name string) string {
+	// String#capitalize.
+	var s string
+	if r, size := utf8.DecodeRuneInString(name); size > 0 {
+		s = string(unicode.ToUpper(r)) + strings.ToLower(name[size:])
+	}
+	s = strings.ReplaceAll(s, "+", "x")
+	// Only the first @ is replaced.
+	if loc := homebrewClassNameAtRe.FindStringSubmatchIndex(s); loc != nil {
+		s = s[:loc[0]] + s[loc[2]:loc[3]] + "AT" + s[loc[4]:loc[5]] + s[loc[1]:]
+	}
+	return homebrewClassNameWordRe.ReplaceAllStringFunc(s, func(m string) string {
+		return strings.ToUpper(m[1:])
+	})
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publishcmd
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestHomebrewClassName(t *testing.T) {
+	c := qt.New(t)
+
+	// The expected values are the class names of existing Homebrew formulae.
+	for _, test := range []struct {
+		name   string
+		expect string
+	}{
+		{"hugo", "Hugo"},
+		{"hugo-extended", "HugoExtended"},
+		{"MyTool", "Mytool"},
+		{"openssl@3", "OpensslAT3"},
+		{"python@3.12", "PythonAT312"},
+		{"gtk+3", "Gtkx3"},
+		{"libxml++3", "Libxmlxx3"},
+		{"foo_bar", "FooBar"},
+		{"ab.cd", "AbCd"},
+		{"node@20", "NodeAT20"},
+		{"c-ares", "CAres"},
+		{"7zip", "7zip"},
+		{"", ""},
+	} {
+		c.Assert(homebrewClassName(test.name), qt.Equals, test.expect, qt.Commentf(test.name))
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releasecmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bep/logg"
+	"github.com/gohugoio/hugoreleaser/internal/common/gith"
+	"github.com/gohugoio/hugoreleaser/internal/common/templ"
+	"github.com/gohugoio/hugoreleaser/internal/config"
+)
+
+// createGitTag creates the tag in the project's Git repository at -commitish,
+// if not already there, and pushes it to the remote.
+// It fails if the tag exists locally or in the remote with a different target.
+func (b *Releaser) createGitTag(rctx releaseContext, settings config.GitTagSettings) error {
+	repo := b.core.ProjectDir
+	tag := b.core.Tag
+	logCtx := rctx.Log.WithField("tag", tag)
+
+	target, err := gith.GitLine(repo, "rev-parse", "--verify", b.commitish+"^{commit}")
+	if err != nil {
+		return fmt.Errorf("%s: failed to resolve -commitish %q: %v", commandName, b.commitish, err)
+	}
+
+	// This fails quietly if the tag does not exist.
+	// Note that git tag --list would treat the tag as a glob pattern.
+	if commit, err := gith.GitLine(repo, "rev-parse", "-q", "--verify", "refs/tags/"+tag+"^{commit}"); err == nil {
+		if commit != target {
+			return fmt.Errorf("%s: tag %q already exists at %s, not at -commitish %q (%s)", commandName, tag, commit, b.commitish, target)
+		}
+		logCtx.Log(logg.String("Git tag already exists"))
+	} else {
+		message, err := templ.Sprintt(settings.MessageTemplate, newTagContext(rctx.Info.Project, tag, rctx.Info.Version))
+		if err != nil {
+			return fmt.Errorf("%s: failed to execute git_tag message_template: %v", commandName, err)
+		}
+		flag := "-a"
+		if settings.Sign {
+			flag = "-s"
+		}
+		if _, err := gith.Git(repo, "tag", flag, "-m", message, tag, target); err != nil {
+			return fmt.Errorf("%s: failed to create tag: %v", commandName, err)
+		}
+		logCtx.Log(logg.String("Created Git tag"))
+	}
+
+	// Each line is "<object> <ref>", the peeled commit of annotated tags in <ref>^{}.
+	out, err := gith.Git(repo, "ls-remote", "--tags", settings.Remote, "refs/tags/"+tag, "refs/tags/"+tag+"^{}")
+	if err != nil {
+		return fmt.Errorf("%s: failed to list tags in remote %q: %v", commandName, settings.Remote, err)
+	}
+	remoteRefs := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if object, ref, found := strings.Cut(line, "\t"); found {
+			remoteRefs[ref] = object
+		}
+	}
+	remoteCommit, found := remoteRefs["refs/tags/"+tag+"^{}"]
+	if !found {
+		remoteCommit, found = remoteRefs["refs/tags/"+tag]
+	}
+	if found {
+		if remoteCommit != target {
+			return fmt.Errorf("%s: tag %q already exists in remote %q at %s, not at -commitish %q (%s)", commandName, tag, settings.Remote, remoteCommit, b.commitish, target)
+		}
+		logCtx.WithField("remote", settings.Remote).Log(logg.String("Git tag already pushed"))
+		return nil
+	}
+
+	if _, err := gith.Git(repo, "push", settings.Remote, "refs/tags/"+tag); err != nil {
+		return fmt.Errorf("%s: failed to push tag: %v", commandName, err)
+	}
+	logCtx.WithField("remote", settings.Remote).Log(logg.String("Pushed Git tag"))
+
+	return nil
+}
@@ -17,26 +17,44 @@ package releasecmd
 import (
 	"context"
 	_ "embed"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"os/exec"
+	"path"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"text/template"
+	"time"
 
 	"github.com/bep/logg"
 	"github.com/gohugoio/hugoreleaser/cmd/corecmd"
+	"github.com/gohugoio/hugoreleaser/internal/common/gith"
 	"github.com/gohugoio/hugoreleaser/internal/common/matchers"
+	"github.com/gohugoio/hugoreleaser/internal/common/semver"
 	"github.com/gohugoio/hugoreleaser/internal/common/templ"
 	"github.com/gohugoio/hugoreleaser/internal/config"
 	"github.com/gohugoio/hugoreleaser/internal/releases"
 	"github.com/gohugoio/hugoreleaser/internal/releases/changelog"
+	"github.com/gohugoio/hugoreleaser/internal/releases/provenance"
+	"github.com/gohugoio/hugoreleaser/internal/releases/signing"
+	"github.com/gohugoio/hugoreleaser/internal/sbom"
 	"github.com/gohugoio/hugoreleaser/staticfiles"
 	"github.com/peterbourgon/ff/v3/ffcli"
 )
 
 const commandName = "release"
 
+// The ordinal of the breaking changes group. The other groups never get ordinal 0,
+// as that defaults to the group's position, so it's left out of the JSON.
+const breakingChangesOrdinal = 0
+
 // New returns a usable ffcli.Command for the release subcommand.
 func New(core *corecmd.Core) *ffcli.Command {
 	fs := flag.NewFlagSet(corecmd.CommandName+" "+commandName, flag.ExitOnError)
@@ -61,6 +79,9 @@ func NewReleaser(core *corecmd.Core, fs *flag.FlagSet) *Releaser {
 	}
 
 	fs.StringVar(&r.commitish, "commitish", "", "The commitish value that determines where the Git tag is created from.")
+	fs.BoolVar(&r.replaceAssets, "replace-assets", false, "Replace assets in an existing release that differ from the local files.")
+	fs.BoolVar(&r.rollbackOnFailure, "rollback-on-failure", false, "Delete the assets uploaded in this run, or the release if this run created it, if any upload fails.")
+	fs.StringVar(&r.prevTag, "prev-tag", "", "The tag to generate the release notes from. Defaults to the closest tag before -tag matching release_notes_settings.tag_pattern.")
 
 	return r
 }
@@ -70,7 +91,10 @@ type Releaser struct {
 	infoLog logg.LevelLogger
 
 	// Flags
-	commitish string
+	commitish         string
+	prevTag           string
+	replaceAssets     bool
+	rollbackOnFailure bool
 }
 
 func (b *Releaser) Init() error {
@@ -78,13 +102,22 @@ func (b *Releaser) Init() error {
 		return fmt.Errorf("%s: flag -commitish is required", commandName)
 	}
 
+	if b.replaceAssets && b.rollbackOnFailure {
+		// The replaced assets are deleted before the upload, so a rollback could not restore them.
+		return fmt.Errorf("%s: flags -replace-assets and -rollback-on-failure cannot be combined, as replaced assets cannot be restored on failure", commandName)
+	}
+
+	if err := b.core.CheckSnapshotRelease(commandName); err != nil {
+		return err
+	}
+
 	b.infoLog = b.core.InfoLog.WithField("cmd", commandName)
 	releaseMatches := b.core.Config.FindReleases(b.core.PathsReleasesCompiled)
 	if len(releaseMatches) == 0 {
 		return fmt.Errorf("%s: no releases found matching -paths %v", commandName, b.core.Paths)
 	}
 	for _, r := range releaseMatches {
-		if err := releases.Validate(r.ReleaseSettings.TypeParsed); err != nil {
+		if err := releases.Validate(r.ReleaseSettings); err != nil {
 			return err
 		}
 	}
@@ -153,6 +186,7 @@ func (b *Releaser) handleRelease(ctx context.Context, logCtx logg.LevelLogger, r
 		Project:   b.core.Config.Project,
 		Tag:       b.core.Tag,
 		Commitish: b.commitish,
+		Version:   b.core.Version,
 		Settings:  release.ReleaseSettings,
 	}
 
@@ -161,7 +195,7 @@ func (b *Releaser) handleRelease(ctx context.Context, logCtx logg.LevelLogger, r
 		client = &releases.FakeClient{}
 	} else {
 		var err error
-		client, err = releases.NewClient(ctx, release.ReleaseSettings.TypeParsed)
+		client, err = releases.NewClient(ctx, release.ReleaseSettings)
 		if err != nil {
 			return fmt.Errorf("%s: failed to create release client: %v", commandName, err)
 		}
@@ -211,11 +245,38 @@ func (b *Releaser) handleRelease(ctx context.Context, logCtx logg.LevelLogger, r
 
 	if len(archiveFilenames) > 0 {
 
-		checksumFilename, checksums, err := b.generateChecksumTxt(rctx, archiveFilenames...)
+		// Use a stable time for the signatures, so running the release again
+		// (e.g. to resume it) produces the same files.
+		sourceDate, err := b.sourceDate(archiveFilenames)
+		if err != nil {
+			return err
+		}
+
+		if len(info.Settings.SBOM.Formats) > 0 {
+			sbomFilenames, err := b.generateSBOMs(rctx, release.ArchsCompiled)
+			if err != nil {
+				return err
+			}
+			archiveFilenames = append(archiveFilenames, sbomFilenames...)
+		}
+
+		checksumFilenames, sidecarFilenames, checksums, err := b.generateChecksumFiles(rctx, archiveFilenames...)
 		if err != nil {
 			return err
 		}
 
+		var signatureFilenames []string
+		if info.Settings.Signing.Type != "" {
+			filesToSign := checksumFilenames
+			if info.Settings.Signing.Archives {
+				filesToSign = append(slices.Clone(archiveFilenames), checksumFilenames...)
+			}
+			signatureFilenames, err = b.signFiles(rctx, sourceDate, filesToSign...)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Store SHA256 checksums in the ArchsCompiled for use by the publish command.
 		for i := range release.ArchsCompiled {
 			if sha, ok := checksums[release.ArchsCompiled[i].Name]; ok {
@@ -223,12 +284,31 @@ func (b *Releaser) handleRelease(ctx context.Context, logCtx logg.LevelLogger, r
 			}
 		}
 
-		archiveFilenames = append(archiveFilenames, checksumFilename)
+		var provenanceFilename string
+		if info.Settings.Provenance.Generate {
+			provenanceFilename, err = b.generateProvenance(rctx, release.ArchsCompiled)
+			if err != nil {
+				return err
+			}
+		}
+
+		archiveFilenames = append(archiveFilenames, checksumFilenames...)
+		archiveFilenames = append(archiveFilenames, sidecarFilenames...)
+		archiveFilenames = append(archiveFilenames, signatureFilenames...)
+		if provenanceFilename != "" {
+			archiveFilenames = append(archiveFilenames, provenanceFilename)
+		}
 
 		logCtx.Logf("Prepared %d files to archive: %v", len(archiveFilenames), archiveFilenames)
 
 	}
 
+	if info.Settings.GitTag.Create {
+		if err := b.createGitTag(rctx, info.Settings.GitTag); err != nil {
+			return err
+		}
+	}
+
 	// Generate release notes if needed.
 	// Write them to the release dir in dist to make testing easier.
 	if info.Settings.ReleaseNotesSettings.Generate {
@@ -240,21 +320,57 @@ func (b *Releaser) handleRelease(ctx context.Context, logCtx logg.LevelLogger, r
 			panic("releaseNotesFilename is empty")
 		}
 		info.Settings.ReleaseNotesSettings.Filename = releaseNotesFilename
+	} else if info.Settings.ReleaseNotesSettings.HasIntro() {
+		// Use the intro alone as the release notes.
+		intro, err := b.readReleaseNotesIntro(rctx)
+		if err != nil {
+			return err
+		}
+		releaseNotesFilename := filepath.Join(rctx.ReleaseDir, "release-notes.md")
+		if err := os.WriteFile(releaseNotesFilename, []byte(intro+"\n"), 0o644); err != nil {
+			return fmt.Errorf("%s: failed to create release notes file %q: %s", commandName, releaseNotesFilename, err)
+		}
+		rctx.Log.WithField("filename", releaseNotesFilename).Log(logg.String("Created release notes"))
+		info.Settings.ReleaseNotesSettings.Filename = releaseNotesFilename
 	}
 
-	// Now create the release archive and upload files.
-	releaseID, err := client.Release(ctx, info)
+	if info.Settings.ReleaseNotesSettings.ChangelogFile != "" {
+		if err := b.updateChangelogFile(rctx, info.Settings.ReleaseNotesSettings); err != nil {
+			return err
+		}
+	}
+
+	// Resume any existing release for this tag, uploading only what's missing.
+	releaseID, archiveFilenames, err := b.resumeRelease(rctx, info, archiveFilenames)
 	if err != nil {
-		return fmt.Errorf("%s: failed to create release: %v", commandName, err)
+		return err
+	}
+
+	createdRelease := releaseID == 0
+	if createdRelease {
+		// Now create the release archive and upload files.
+		releaseID, err = client.Release(ctx, info)
+		if err != nil {
+			return fmt.Errorf("%s: failed to create release: %v", commandName, err)
+		}
 	}
 	r, ctx := b.core.Workforce.Start(ctx)
 
+	var (
+		uploadedMu sync.Mutex
+		uploaded   []string
+	)
+
 	for _, archiveFilename := range archiveFilenames {
 		archiveFilename := archiveFilename
 		r.Run(func() error {
 			openFile := func() (*os.File, error) {
 				return os.Open(archiveFilename)
 			}
+			// Register the file before the upload starts, as a failed upload may leave a partial asset behind.
+			uploadedMu.Lock()
+			uploaded = append(uploaded, filepath.Base(archiveFilename))
+			uploadedMu.Unlock()
 			logCtx.Logf("Uploading release file %s", archiveFilename)
 			if err := releases.UploadAssetsFileWithRetries(ctx, client, info, releaseID, openFile); err != nil {
 				return err
@@ -263,20 +379,165 @@ func (b *Releaser) handleRelease(ctx context.Context, logCtx logg.LevelLogger, r
 		})
 	}
 
-	if err := r.Wait(); err != nil {
+	err = r.Wait()
+	if err == nil {
+		// Some clients, e.g. S3, update the latest files once all files are uploaded.
+		if finalizer, ok := client.(releases.FinalizeClient); ok {
+			err = finalizer.FinalizeRelease(rctx.Ctx, info, releaseID)
+		}
+	}
+	if err != nil {
+		if b.rollbackOnFailure {
+			if rerr := b.rollback(rctx, info, releaseID, createdRelease, uploaded); rerr != nil {
+				return fmt.Errorf("%s: failed to upload files: %v; rollback failed: %v", commandName, err, rerr)
+			}
+		}
 		return fmt.Errorf("%s: failed to upload files: %v", commandName, err)
 	}
 
 	return nil
 }
 
+// rollback deletes the release if it was created in this run,
+// else it deletes the assets with the given names uploaded to the existing release.
+func (b *Releaser) rollback(rctx releaseContext, info releases.ReleaseInfo, releaseID int64, createdRelease bool, names []string) error {
+	// The context passed to the workers is cancelled on the first error.
+	ctx := context.WithoutCancel(rctx.Ctx)
+	sort.Strings(names)
+
+	if createdRelease {
+		if err := rctx.Client.DeleteRelease(ctx, info, releaseID); err != nil {
+			return err
+		}
+		rctx.Log.Logf("Rolled back: deleted release with ID %d and its files %v", releaseID, names)
+		return nil
+	}
+
+	client, ok := rctx.Client.(releases.AssetsClient)
+	if !ok {
+		return fmt.Errorf("release client %T does not support deleting assets", rctx.Client)
+	}
+	assets, err := client.ListAssets(ctx, info, releaseID)
+	if err != nil {
+		return err
+	}
+	var deleted []string
+	for _, asset := range assets {
+		if slices.Contains(names, asset.Name) {
+			if err := client.DeleteAsset(ctx, info, releaseID, asset); err != nil {
+				return err
+			}
+			deleted = append(deleted, asset.Name)
+		}
+	}
+	rctx.Log.Logf("Rolled back: deleted files %v from existing release with ID %d", deleted, releaseID)
+	return nil
+}
+
+// resumeRelease looks for an existing release for the tag.
+// If found, it returns its ID and the files in filenames not already uploaded to it.
+// If not found, or if the client does not support listing assets, it returns a zero ID and all filenames.
+func (b *Releaser) resumeRelease(rctx releaseContext, info releases.ReleaseInfo, filenames []string) (int64, []string, error) {
+	client, ok := rctx.Client.(releases.AssetsClient)
+	if !ok {
+		return 0, filenames, nil
+	}
+
+	settings := info.Settings
+	releaseID, _, err := client.GetReleaseByTag(rctx.Ctx, settings.RepositoryOwner, settings.Repository, info.Tag)
+	if err != nil {
+		if errors.Is(err, releases.ErrReleaseNotFound) {
+			return 0, filenames, nil
+		}
+		return 0, nil, fmt.Errorf("%s: failed to get release: %v", commandName, err)
+	}
+
+	rctx.Log.Logf("Found existing release with ID %d, resuming", releaseID)
+
+	assets, err := client.ListAssets(rctx.Ctx, info, releaseID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s: failed to list release assets: %v", commandName, err)
+	}
+	assetsByName := make(map[string]releases.Asset)
+	for _, asset := range assets {
+		assetsByName[asset.Name] = asset
+	}
+
+	var missing []string
+	for _, filename := range filenames {
+		name := filepath.Base(filename)
+		asset, found := assetsByName[name]
+		if !found {
+			missing = append(missing, filename)
+			continue
+		}
+
+		if !asset.Incomplete {
+			if asset.SHA256 == "" {
+				if !b.replaceAssets {
+					return 0, nil, fmt.Errorf("%s: cannot verify release asset %q against %q, no checksum available; use -replace-assets to replace it", commandName, name, filename)
+				}
+			} else {
+				matches, err := assetMatchesFile(asset, filename)
+				if err != nil {
+					return 0, nil, err
+				}
+				if matches {
+					rctx.Log.Logf("Skipping release file %s, already uploaded", filename)
+					continue
+				}
+				if !b.replaceAssets {
+					return 0, nil, fmt.Errorf("%s: release asset %q differs from %q; use -replace-assets to replace it", commandName, name, filename)
+				}
+			}
+		}
+
+		rctx.Log.Logf("Deleting release asset %s", name)
+		if err := client.DeleteAsset(rctx.Ctx, info, releaseID, asset); err != nil {
+			return 0, nil, fmt.Errorf("%s: failed to delete release asset %q: %v", commandName, name, err)
+		}
+		missing = append(missing, filename)
+	}
+
+	return releaseID, missing, nil
+}
+
+// assetMatchesFile reports whether the uploaded asset has the same size and SHA256 checksum as filename.
+func assetMatchesFile(asset releases.Asset, filename string) (bool, error) {
+	fi, err := os.Stat(filename)
+	if err != nil {
+		return false, err
+	}
+	if fi.Size() != asset.Size {
+		return false, nil
+	}
+	checksum, err := releases.SHA256File(filename)
+	if err != nil {
+		return false, err
+	}
+	return checksum == asset.SHA256, nil
+}
+
 func (b *Releaser) generateReleaseNotes(rctx releaseContext) (string, error) {
 	if rctx.Info.Settings.ReleaseNotesSettings.Filename != "" {
 		return "", fmt.Errorf("%s: both GenerateReleaseNotes and ReleaseNotesFilename are set for release type %q", commandName, rctx.Info.Settings.Type)
 	}
 
+	releaseNotesSettings := rctx.Info.Settings.ReleaseNotesSettings
+
+	var intro string
+	if releaseNotesSettings.HasIntro() {
+		var err error
+		if intro, err = b.readReleaseNotesIntro(rctx); err != nil {
+			return "", err
+		}
+	}
+
+	enricher, enrich := rctx.Client.(releases.ChangesEnricher)
+	enrich = enrich && releaseNotesSettings.Enrich
+
 	var resolveUsername func(commit, author string) (string, error)
-	if unc, ok := rctx.Client.(releases.UsernameResolver); ok {
+	if unc, ok := rctx.Client.(releases.UsernameResolver); ok && !enrich {
 		resolveUsername = func(commit, author string) (string, error) {
 			return unc.ResolveUsername(rctx.Ctx, commit, author, rctx.Info)
 		}
@@ -284,8 +545,11 @@ func (b *Releaser) generateReleaseNotes(rctx releaseContext) (string, error) {
 
 	infos, err := changelog.CollectChanges(
 		changelog.Options{
+			PrevTag:         b.prevTag,
 			Tag:             b.core.Tag,
 			Commitish:       b.commitish,
+			TagPattern:      releaseNotesSettings.TagPattern,
+			Paths:           releaseNotesSettings.PathFilter,
 			RepoPath:        os.Getenv("HUGORELEASER_CHANGELOG_GITREPO"), // Set in tests.
 			ResolveUserName: resolveUsername,
 		},
@@ -294,10 +558,24 @@ func (b *Releaser) generateReleaseNotes(rctx releaseContext) (string, error) {
 		return "", err
 	}
 
-	changeGroups := rctx.Info.Settings.ReleaseNotesSettings.Groups
-	shortThreshold := rctx.Info.Settings.ReleaseNotesSettings.ShortThreshold
+	var contributors, newContributors []changelog.Contributor
+	if enrich {
+		// Shared between releases and tags.
+		cacheFilename := filepath.Join(b.core.DistDir, b.core.Config.Project, "github-changes-cache.json")
+		newContributorNames, err := enricher.EnrichChanges(rctx.Ctx, rctx.Info, infos, cacheFilename)
+		if err != nil {
+			return "", err
+		}
+		contributors = infos.Contributors()
+		if len(newContributorNames) > 0 {
+			newContributors = infos.Contributors(newContributorNames...)
+		}
+	}
+
+	changeGroups := releaseNotesSettings.Groups
+	shortThreshold := releaseNotesSettings.ShortThreshold
 	if shortThreshold > 0 && len(infos) < shortThreshold {
-		shortTitle := rctx.Info.Settings.ReleaseNotesSettings.ShortTitle
+		shortTitle := releaseNotesSettings.ShortTitle
 		if shortTitle == "" {
 			shortTitle = "What's Changed"
 		}
@@ -312,12 +590,17 @@ func (b *Releaser) generateReleaseNotes(rctx releaseContext) (string, error) {
 		changeGroups = changeGroupsShort
 	}
 
+	breakingChangesTitle := releaseNotesSettings.BreakingChangesTitle
+
 	infosGrouped, err := changelog.GroupByTitleFunc(infos, func(change changelog.Change) (string, int, bool) {
 		for i, g := range changeGroups {
-			if g.RegexpCompiled.Match(change.Subject) {
+			if g.Matches(change.Type, change.Scope, change.Subject, change.Labels()) {
 				if g.Ignore {
 					return "", 0, false
 				}
+				if change.Breaking {
+					break
+				}
 				ordinal := g.Ordinal
 				if ordinal == 0 {
 					ordinal = i + 1
@@ -325,18 +608,39 @@ func (b *Releaser) generateReleaseNotes(rctx releaseContext) (string, error) {
 				return g.Title, ordinal, true
 			}
 		}
+		if change.Breaking {
+			return breakingChangesTitle, breakingChangesOrdinal, true
+		}
 		return "", 0, false
 	})
 	if err != nil {
 		return "", err
 	}
+	// Breaking changes are always listed first, also before groups with a negative ordinal.
+	if i := slices.IndexFunc(infosGrouped, func(g changelog.TitleChanges) bool { return g.Ordinal == breakingChangesOrdinal }); i > 0 {
+		breaking := infosGrouped[i]
+		infosGrouped = slices.Insert(slices.Delete(infosGrouped, i, i+1), 0, breaking)
+	}
 
 	type ReleaseNotesContext struct {
+		semver.Version
+
+		// Set if release_notes_settings.intro_from_tag or intro_filename_template is set.
+		Intro string
+
 		ChangeGroups []changelog.TitleChanges
+
+		// Set if release_notes_settings.enrich is enabled.
+		Contributors    []changelog.Contributor
+		NewContributors []changelog.Contributor
 	}
 
 	rnc := ReleaseNotesContext{
-		ChangeGroups: infosGrouped,
+		Version:         rctx.Info.Version,
+		Intro:           intro,
+		ChangeGroups:    infosGrouped,
+		Contributors:    contributors,
+		NewContributors: newContributors,
 	}
 
 	releaseNotesFilename := filepath.Join(rctx.ReleaseDir, "release-notes.md")
@@ -378,40 +682,319 @@ func (b *Releaser) generateReleaseNotes(rctx releaseContext) (string, error) {
 
 	rctx.Log.WithField("filename", releaseNotesFilename).Log(logg.String("Created release notes"))
 
+	// Also write the release notes as JSON for other tools to consume.
+	releaseNotesJSON, err := json.MarshalIndent(infosGrouped, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	releaseNotesJSONFilename := filepath.Join(rctx.ReleaseDir, "release-notes.json")
+	if err := os.WriteFile(releaseNotesJSONFilename, releaseNotesJSON, 0o644); err != nil {
+		return "", fmt.Errorf("%s: failed to create release notes file %q: %s", commandName, releaseNotesJSONFilename, err)
+	}
+
 	return releaseNotesFilename, nil
 }
 
-func (b *Releaser) generateChecksumTxt(rctx releaseContext, archiveFilenames ...string) (string, map[string]string, error) {
-	// Create a checksums.txt file.
-	checksumResult, err := releases.CreateChecksumLines(b.core.Workforce, archiveFilenames...)
+// readReleaseNotesIntro reads the release notes intro from the annotated tag message
+// or the intro file in the project.
+// tagContext is the template context for the release templates that depend on the tag only.
+// {{ .Version }} prints the version without the v prefix.
+type tagContext struct {
+	Project string
+	Tag     string
+	semver.Version
+}
+
+func newTagContext(project, tag string, version semver.Version) tagContext {
+	return tagContext{Project: project, Tag: tag, Version: version}
+}
+
+func (b *Releaser) readReleaseNotesIntro(rctx releaseContext) (string, error) {
+	settings := rctx.Info.Settings.ReleaseNotesSettings
+
+	if settings.IntroFromTag {
+		intro, err := changelog.TagMessage(os.Getenv("HUGORELEASER_CHANGELOG_GITREPO"), b.core.Tag)
+		if err != nil {
+			return "", fmt.Errorf("%s: failed to read release notes intro: %v", commandName, err)
+		}
+		return intro, nil
+	}
+
+	name, err := templ.Sprintt(settings.IntroFilenameTemplate, newTagContext(rctx.Info.Project, rctx.Info.Tag, rctx.Info.Version))
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to execute intro_filename_template: %v", commandName, err)
+	}
+	filename := filepath.Join(b.core.ProjectDir, filepath.FromSlash(name))
+	intro, err := os.ReadFile(filename)
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to read release notes intro: %v", commandName, err)
+	}
+	return strings.TrimSpace(string(intro)), nil
+}
+
+// updateChangelogFile adds the release notes to the changelog file in the project
+// and writes the result to the release dir, see the changelog publisher.
+func (b *Releaser) updateChangelogFile(rctx releaseContext, settings config.ReleaseNotesSettings) error {
+	notes, err := os.ReadFile(settings.Filename)
 	if err != nil {
-		return "", nil, err
+		return fmt.Errorf("%s: failed to read release notes: %v", commandName, err)
 	}
-	// This is what Hugo got out of the box from Goreleaser. No settings for now.
-	name := fmt.Sprintf("%s_%s_checksums.txt", rctx.Info.Project, strings.TrimPrefix(rctx.Info.Tag, "v"))
 
-	checksumFilename := filepath.Join(rctx.ReleaseDir, name)
-	err = func() error {
-		f, err := os.Create(checksumFilename)
+	var content []byte
+	sourceFilename := filepath.Join(b.core.ProjectDir, filepath.FromSlash(settings.ChangelogFile))
+	if content, err = os.ReadFile(sourceFilename); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("%s: failed to read changelog file: %v", commandName, err)
+	}
+
+	date := time.Now().UTC().Format("2006-01-02")
+	updated := changelog.UpdateKeepAChangelog(string(content), b.core.Tag, date, string(notes))
+
+	filename := filepath.Join(rctx.ReleaseDir, path.Base(settings.ChangelogFile))
+	if err := os.WriteFile(filename, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("%s: failed to write changelog file: %v", commandName, err)
+	}
+	rctx.Log.WithField("filename", filename).Log(logg.String("Updated changelog file"))
+
+	return nil
+}
+
+// generateChecksumFiles creates one checksum file per configured algorithm and,
+// if enabled, a sidecar checksum file per archive.
+// It returns the checksum filenames, the sidecar filenames and a map of base filename -> SHA256 checksum.
+func (b *Releaser) generateChecksumFiles(rctx releaseContext, archiveFilenames ...string) ([]string, []string, map[string]string, error) {
+	settings := rctx.Info.Settings.Checksums
+
+	var checksumFilenames []string
+	var sha256Checksums map[string]string
+	var sidecars []string
+	seen := make(map[string]bool)
+
+	for _, algorithm := range settings.Algorithms {
+		checksumResult, err := releases.CreateChecksums(b.core.Workforce, algorithm, settings.Format, archiveFilenames...)
 		if err != nil {
-			return err
+			return nil, nil, nil, err
+		}
+		if algorithm == "sha256" {
+			sha256Checksums = checksumResult.Checksums
 		}
-		defer f.Close()
 
-		for _, line := range checksumResult.Lines {
-			_, err := f.WriteString(line + "\n")
-			if err != nil {
-				return err
+		name, err := templ.Sprintt(settings.NameTemplate, struct {
+			Project   string
+			Tag       string
+			Algorithm string
+			semver.Version
+		}{
+			Project:   rctx.Info.Project,
+			Tag:       rctx.Info.Tag,
+			Algorithm: algorithm,
+			Version:   rctx.Info.Version,
+		})
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%s: failed to execute checksums name template: %v", commandName, err)
+		}
+		if seen[name] {
+			return nil, nil, nil, fmt.Errorf("%s: checksums name_template must create a unique name per algorithm, got %q for %q", commandName, name, algorithm)
+		}
+		seen[name] = true
+
+		checksumFilename := filepath.Join(rctx.ReleaseDir, name)
+		if err := writeLines(checksumFilename, checksumResult.Lines); err != nil {
+			return nil, nil, nil, fmt.Errorf("%s: failed to create checksum file %q: %s", commandName, checksumFilename, err)
+		}
+		rctx.Log.WithField("filename", checksumFilename).Log(logg.String("Created checksum file"))
+		checksumFilenames = append(checksumFilenames, checksumFilename)
+
+		if settings.Sidecars {
+			for _, archiveFilename := range archiveFilenames {
+				baseName := filepath.Base(archiveFilename)
+				line, err := releases.ChecksumLine(algorithm, settings.Format, checksumResult.Checksums[baseName], baseName)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				sidecarFilename := filepath.Join(rctx.ReleaseDir, baseName+"."+algorithm)
+				if err := writeLines(sidecarFilename, []string{line}); err != nil {
+					return nil, nil, nil, fmt.Errorf("%s: failed to create checksum file %q: %s", commandName, sidecarFilename, err)
+				}
+				sidecars = append(sidecars, sidecarFilename)
 			}
 		}
+	}
 
-		return nil
-	}()
+	if sha256Checksums == nil {
+		// The publishers need the SHA256 checksums, e.g. for Homebrew.
+		checksumResult, err := releases.CreateChecksumLines(b.core.Workforce, archiveFilenames...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		sha256Checksums = checksumResult.Checksums
+	}
+
+	return checksumFilenames, sidecars, sha256Checksums, nil
+}
+
+// sourceDate returns the time to use for the release artifacts created by this command:
+// the SOURCE_DATE_EPOCH env var if set, else the commit time of -commitish,
+// else the latest modification time of the archives.
+func (b *Releaser) sourceDate(archiveFilenames []string) (time.Time, error) {
+	if v := os.Getenv("SOURCE_DATE_EPOCH"); v != "" {
+		sec, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%s: invalid SOURCE_DATE_EPOCH %q: %v", commandName, v, err)
+		}
+		return time.Unix(sec, 0).UTC(), nil
+	}
+
+	// This fails if the project is not a Git repository or -commitish is not known locally.
+	if v, err := gith.GitLine(b.core.ProjectDir, "log", "-1", "--format=%ct", b.commitish); err == nil {
+		if sec, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.Unix(sec, 0).UTC(), nil
+		}
+	}
+
+	var latest time.Time
+	for _, filename := range archiveFilenames {
+		fi, err := os.Stat(filename)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+	return latest.Truncate(time.Second).UTC(), nil
+}
+
+// signFiles creates a detached signature in the release dir for each of the given files,
+// marked as created at signedAt.
+func (b *Releaser) signFiles(rctx releaseContext, signedAt time.Time, filenames ...string) ([]string, error) {
+	signer, err := signing.New(rctx.Info.Settings.Signing, signedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", commandName, err)
+	}
+
+	var signatureFilenames []string
+	for _, filename := range filenames {
+		signatureFilename, err := signing.SignFile(signer, filename, rctx.ReleaseDir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", commandName, err)
+		}
+		rctx.Log.WithField("filename", signatureFilename).Log(logg.String("Created signature file"))
+		signatureFilenames = append(signatureFilenames, signatureFilename)
+	}
+
+	return signatureFilenames, nil
+}
+
+// generateProvenance creates a signed <project>_<version>.intoto.jsonl provenance attestation
+// in the release dir for the given archives.
+func (b *Releaser) generateProvenance(rctx releaseContext, archives []config.BuildArchPath) (string, error) {
+	settings := rctx.Info.Settings.Provenance
+
+	key, passphrase, err := signing.ReadKey(settings.KeyFile, settings.KeyEnv, settings.PassphraseEnv)
 	if err != nil {
-		return "", nil, fmt.Errorf("%s: failed to create checksum file %q: %s", commandName, checksumFilename, err)
+		return "", fmt.Errorf("%s: provenance: %v", commandName, err)
+	}
+	signer, err := provenance.ParsePrivateKey(key, passphrase)
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", commandName, err)
 	}
 
-	rctx.Log.WithField("filename", checksumFilename).Log(logg.String("Created checksum file"))
+	goVersion, err := exec.CommandContext(rctx.Ctx, b.core.Config.BuildSettings.GoSettings.GoExe, "env", "GOVERSION").Output()
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to get Go version: %v", commandName, err)
+	}
 
-	return checksumFilename, checksumResult.Checksums, nil
+	statement, err := provenance.NewStatement(provenance.Options{
+		Project:        rctx.Info.Project,
+		Tag:            rctx.Info.Tag,
+		Commitish:      rctx.Info.Commitish,
+		BuilderID:      settings.BuilderID,
+		ConfigFilename: b.core.ConfigFile,
+		GoVersion:      strings.TrimSpace(string(goVersion)),
+		Archives:       archives,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", commandName, err)
+	}
+
+	envelope, err := provenance.Sign(statement, signer)
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", commandName, err)
+	}
+	envelopeJSON, err := json.Marshal(envelope)
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%s_%s.intoto.jsonl", rctx.Info.Project, strings.TrimPrefix(rctx.Info.Tag, "v"))
+	filename := filepath.Join(rctx.ReleaseDir, name)
+	if err := writeLines(filename, []string{string(envelopeJSON)}); err != nil {
+		return "", fmt.Errorf("%s: failed to create provenance file %q: %s", commandName, filename, err)
+	}
+	rctx.Log.WithField("filename", filename).Log(logg.String("Created provenance file"))
+
+	return filename, nil
+}
+
+// generateSBOMs creates one <project>_<version><ext> SBOM file per configured format
+// in the release dir, covering the binaries of the given archives.
+func (b *Releaser) generateSBOMs(rctx releaseContext, archives []config.BuildArchPath) ([]string, error) {
+	var binaries []sbom.Binary
+	seen := make(map[string]bool)
+	for _, archive := range archives {
+		binaryPath := archive.Arch.BinaryPath()
+		if seen[binaryPath] {
+			continue
+		}
+		seen[binaryPath] = true
+		binaries = append(binaries, sbom.Binary{
+			Name:   archive.Arch.BuildSettings.Binary,
+			Goos:   archive.Arch.Os.Goos,
+			Goarch: archive.Arch.Goarch,
+			Filename: filepath.Join(
+				b.core.DistDir,
+				b.core.Config.Project,
+				b.core.Tag,
+				b.core.DistRootBuilds,
+				filepath.FromSlash(binaryPath),
+			),
+		})
+	}
+
+	var filenames []string
+	for _, format := range rctx.Info.Settings.SBOM.Formats {
+		content, err := sbom.Generate(format, sbom.Options{
+			Project:  rctx.Info.Project,
+			Tag:      rctx.Info.Tag,
+			Binaries: binaries,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", commandName, err)
+		}
+		name := fmt.Sprintf("%s_%s%s", rctx.Info.Project, strings.TrimPrefix(rctx.Info.Tag, "v"), sbom.Extension(format))
+		filename := filepath.Join(rctx.ReleaseDir, name)
+		if err := os.WriteFile(filename, content, 0o644); err != nil {
+			return nil, fmt.Errorf("%s: failed to create SBOM file %q: %s", commandName, filename, err)
+		}
+		rctx.Log.WithField("filename", filename).Log(logg.String("Created SBOM file"))
+		filenames = append(filenames, filename)
+	}
+
+	return filenames, nil
+}
+
+func writeLines(filename string, lines []string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for _, line := range lines {
+		if _, err := f.WriteString(line + "\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -12,7 +12,7 @@ require (
 	github.com/gohugoio/hugoreleaser/plugins v0.1.1-0.20220822083757-38d81884db04
 	github.com/google/go-github/v45 v45.2.0
 	github.com/mattn/go-isatty v0.0.20
-	github.com/mitchellh/mapstructure v1.5.0
+	github.com/mitchellh/mapstructure v1.5.0 // indirect
 	github.com/pelletier/go-toml/v2 v2.2.4
 	github.com/peterbourgon/ff/v3 v3.4.0
 	github.com/rogpeppe/go-internal v1.14.1
@@ -24,17 +24,20 @@ require (
 	github.com/bep/clocks v0.5.0 // indirect
 	github.com/mattn/go-colorable v0.1.14 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
-	golang.org/x/crypto v0.47.0 // indirect
+	golang.org/x/crypto v0.47.0
 	golang.org/x/sys v0.40.0 // indirect
 )
 
 require (
+	github.com/ProtonMail/go-crypto v1.5.2
 	github.com/goccy/go-yaml v1.19.2
 	github.com/gohugoio/hugoreleaser-plugins-api v0.8.0
+	golang.org/x/mod v0.32.0
 	golang.org/x/sync v0.19.0
 )
 
 require (
+	github.com/cloudflare/circl v1.6.3 // indirect
 	github.com/google/go-querystring v1.2.0 // indirect
 	golang.org/x/tools v0.41.0 // indirect
 )
@@ -0,0 +1,46 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gith
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Git runs git with the given args in repo and returns the combined output.
+// If repo is empty, the current working directory is used.
+func Git(repo string, args ...string) (string, error) {
+	if repo != "" {
+		args = append([]string{"-C", repo}, args...)
+	}
+
+	cmd := exec.Command("git", args...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git failed: %q: %q (%q)", err, out, args)
+	}
+	return string(out), nil
+}
+
+// GitLine is like Git, but returns the first line of the output with any surrounding whitespace removed.
+func GitLine(repo string, args ...string) (string, error) {
+	out, err := Git(repo, args...)
+	if err != nil {
+		return "", err
+	}
+	line, _, _ := strings.Cut(out, "\n")
+	return strings.TrimSpace(line), nil
+}
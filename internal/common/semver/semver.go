@@ -0,0 +1,77 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package semver parses semantic versions (https://semver.org) from Git tags.
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The regexp suggested on semver.org, with an optional v prefix.
+var versionRe = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// Version is a parsed semantic version.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+
+	// The pre-release part, e.g. rc1 in v1.2.3-rc1.
+	Prerelease string
+
+	// The build metadata part, e.g. 20260101 in v1.2.3+20260101.
+	Metadata string
+}
+
+// IsPrerelease reports whether v has a pre-release part.
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// String returns v without the v prefix, e.g. 1.2.3-rc1+20260101.
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Metadata != "" {
+		s += "+" + v.Metadata
+	}
+	return s
+}
+
+// Parse parses the semantic version in tag, with or without a v prefix.
+// Any path prefix, e.g. tools/ in tools/v1.2.3 as used in monorepos, is ignored.
+func Parse(tag string) (Version, error) {
+	m := versionRe.FindStringSubmatch(tag[strings.LastIndex(tag, "/")+1:])
+	if m == nil {
+		return Version{}, fmt.Errorf("%q is not a valid semantic version, e.g. v1.2.3", tag)
+	}
+	var (
+		v   Version
+		err error
+	)
+	for i, p := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		if *p, err = strconv.Atoi(m[i+1]); err != nil {
+			return Version{}, fmt.Errorf("%q is not a valid semantic version: %v", tag, err)
+		}
+	}
+	v.Prerelease = m[4]
+	v.Metadata = m[5]
+	return v, nil
+}
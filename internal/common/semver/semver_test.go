@@ -0,0 +1,50 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semver
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParse(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		tag    string
+		expect Version
+	}{
+		{"v1.2.3", Version{Major: 1, Minor: 2, Patch: 3}},
+		{"0.120.0", Version{Minor: 120}},
+		{"v0.120.0-rc1", Version{Minor: 120, Prerelease: "rc1"}},
+		{"v1.0.0-alpha.1+build.5", Version{Major: 1, Prerelease: "alpha.1", Metadata: "build.5"}},
+		{"v1.0.0+20260101", Version{Major: 1, Metadata: "20260101"}},
+		{"tools/v1.2.3", Version{Major: 1, Minor: 2, Patch: 3}},
+	} {
+		v, err := Parse(test.tag)
+		c.Assert(err, qt.IsNil, qt.Commentf(test.tag))
+		c.Assert(v, qt.Equals, test.expect)
+	}
+
+	v, _ := Parse("v1.0.0-alpha.1+build.5")
+	c.Assert(v.String(), qt.Equals, "1.0.0-alpha.1+build.5")
+	c.Assert(v.IsPrerelease(), qt.IsTrue)
+
+	for _, tag := range []string{"", "v1", "v1.2", "1.2.3.4", "v01.2.3", "v1.2.3-", "v1.2.3-01", "release-1.2.3", "tools/", "vv1.2.3"} {
+		_, err := Parse(tag)
+		c.Assert(err, qt.ErrorMatches, `.*is not a valid semantic version.*`, qt.Commentf(tag))
+	}
+}
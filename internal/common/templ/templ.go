@@ -37,6 +37,15 @@ var BuiltInFuncs = template.FuncMap{
 	"trimSuffix": func(suffix, s string) string {
 		return strings.TrimSuffix(s, suffix)
 	},
+	"indent": func(n int, s string) string {
+		lines := strings.Split(s, "\n")
+		for i, line := range lines {
+			if line != "" {
+				lines[i] = strings.Repeat(" ", n) + line
+			}
+		}
+		return strings.Join(lines, "\n")
+	},
 }
 
 // Sprintt renders the Go template t with the given data in ctx.
@@ -86,9 +95,12 @@ func fieldsFromObject(in any) []string {
 	if v.Kind() != reflect.Struct {
 		return fields
 	}
-	t := v.Type()
-	for i := 0; i < v.NumField(); i++ {
-		fields = append(fields, "."+t.Field(i).Name)
+	// Include the fields promoted from embedded structs.
+	for _, f := range reflect.VisibleFields(v.Type()) {
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+		fields = append(fields, "."+f.Name)
 	}
 	return fields
 
@@ -28,4 +28,12 @@ func TestSprintt(t *testing.T) {
 	c.Assert(MustSprintt("{{ . | lower }}", "FoO"), qt.Equals, "foo")
 	c.Assert(MustSprintt("{{ . | trimPrefix `v` }}", "v3.0.0"), qt.Equals, "3.0.0")
 	c.Assert(MustSprintt("{{ . | trimSuffix `-beta` }}", "v3.0.0-beta"), qt.Equals, "v3.0.0")
+	c.Assert(MustSprintt("{{ . | indent 2 }}", "foo\n\nbar"), qt.Equals, "  foo\n\n  bar")
+
+	type embedded struct{ Major int }
+	_, err := Sprintt("{{ .Minor }}", struct {
+		Tag string
+		embedded
+	}{Tag: "v3.0.0"})
+	c.Assert(err, qt.ErrorMatches, `.*available fields: \[\.Tag \.Major\]`)
 }
@@ -16,10 +16,12 @@ package config
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/gohugoio/hugoreleaser/internal/archives/archiveformats"
 	"github.com/gohugoio/hugoreleaser/internal/common/matchers"
+	"github.com/gohugoio/hugoreleaser/internal/sbom"
 	"github.com/gohugoio/hugoreleaser/plugins/model"
 )
 
@@ -86,6 +88,14 @@ type ArchiveSettings struct {
 	Replacements map[string]string `json:"replacements"`
 	Plugin       Plugin            `json:"plugin"`
 
+	// SBOM configures the SBOM files added to the archive, one per format,
+	// created from the Go build info embedded in the binary.
+	SBOM SBOMSettings `json:"sbom"`
+
+	// ThirdPartyLicenses configures the license notices of the
+	// modules linked into the binary added to the archive.
+	ThirdPartyLicenses ThirdPartyLicensesSettings `json:"third_party_licenses"`
+
 	// CustomSettings is archive type specific metadata.
 	// See in the documentation for the configured archive type.
 	CustomSettings map[string]any `json:"custom_settings"`
@@ -112,6 +122,14 @@ func (a *ArchiveSettings) Init() error {
 
 	}
 
+	if err := a.SBOM.Init(); err != nil {
+		return fmt.Errorf("%s: %v", what, err)
+	}
+
+	if err := a.ThirdPartyLicenses.Init(); err != nil {
+		return fmt.Errorf("%s: %v", what, err)
+	}
+
 	var oldNew []string
 	for k, v := range a.Replacements {
 		oldNew = append(oldNew, k, v)
@@ -122,6 +140,83 @@ func (a *ArchiveSettings) Init() error {
 	return nil
 }
 
+// SBOMSettings configures the software bill of materials (SBOM) created
+// from the Go build info embedded in the binaries.
+type SBOMSettings struct {
+	// The SBOM formats to create, one or more of cyclonedx and spdx.
+	Formats []string `json:"formats"`
+}
+
+func (s *SBOMSettings) Init() error {
+	what := "sbom"
+	for i, format := range s.Formats {
+		format = strings.ToLower(format)
+		if !slices.Contains(sbom.Formats, format) {
+			return fmt.Errorf("%s: invalid format %q, must be one of %v", what, format, sbom.Formats)
+		}
+		s.Formats[i] = format
+	}
+	return nil
+}
+
+// IsZero is needed to get the shallow merge correct.
+func (s SBOMSettings) IsZero() bool {
+	return len(s.Formats) == 0
+}
+
+const (
+	ThirdPartyLicensesFormatDir  = "dir"
+	ThirdPartyLicensesFormatFile = "file"
+)
+
+// ThirdPartyLicensesSettings configures the license files collected from the
+// Go module cache for the modules linked into the binary.
+type ThirdPartyLicensesSettings struct {
+	// Either dir (one directory per module) or file (a single concatenated notice file).
+	// Empty means no license files.
+	Format string `json:"format"`
+
+	// The path inside the archive.
+	// Defaults to THIRD_PARTY_LICENSES for dir and THIRD_PARTY_LICENSES.txt for file.
+	TargetPath string `json:"target_path"`
+
+	// License types (SPDX identifiers) to fail on, matched as a case insensitive prefix,
+	// e.g. AGPL or GPL-3.0. Use Unknown to fail on licenses that could not be detected.
+	Deny []string `json:"deny"`
+
+	// Module paths to skip, e.g. modules in the same repository without their own license file.
+	Ignore []string `json:"ignore"`
+}
+
+func (s *ThirdPartyLicensesSettings) Init() error {
+	what := "third_party_licenses"
+	switch s.Format {
+	case "":
+		return nil
+	case ThirdPartyLicensesFormatDir:
+		if s.TargetPath == "" {
+			s.TargetPath = "THIRD_PARTY_LICENSES"
+		}
+	case ThirdPartyLicensesFormatFile:
+		if s.TargetPath == "" {
+			s.TargetPath = "THIRD_PARTY_LICENSES.txt"
+		}
+	default:
+		return fmt.Errorf("%s: invalid format %q, must be one of %s or %s", what, s.Format, ThirdPartyLicensesFormatDir, ThirdPartyLicensesFormatFile)
+	}
+	for _, d := range s.Deny {
+		if strings.TrimSpace(d) == "" {
+			return fmt.Errorf("%s: deny: empty license type", what)
+		}
+	}
+	return nil
+}
+
+// IsZero is needed to get the shallow merge correct.
+func (s ThirdPartyLicensesSettings) IsZero() bool {
+	return s.Format == "" && s.TargetPath == "" && len(s.Deny) == 0 && len(s.Ignore) == 0
+}
+
 type ArchiveType struct {
 	Format    string `json:"format"`
 	Extension string `json:"extension"`
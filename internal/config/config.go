@@ -33,6 +33,9 @@ type Config struct {
 	Project                  string            `json:"project"`
 	ArchiveAliasReplacements map[string]string `json:"archive_alias_replacements"`
 
+	// By default, -tag must be a semantic version, e.g. v1.2.3.
+	AllowNonSemver bool `json:"allow_non_semver"`
+
 	GoSettings GoSettings `json:"go_settings"`
 
 	Builds     Builds     `json:"builds"`
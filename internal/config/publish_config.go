@@ -20,6 +20,7 @@ import (
 
 	"github.com/gohugoio/hugoreleaser/internal/common/matchers"
 	"github.com/gohugoio/hugoreleaser/internal/publish/publishformats"
+	"github.com/gohugoio/hugoreleaser/internal/releases/releasetypes"
 )
 
 // PublishSettings contains shared defaults for publishers.
@@ -48,6 +49,7 @@ type Publisher struct {
 
 	// CustomSettings contains type-specific settings.
 	// For homebrew_cask: bundle_identifier, tap_repository, name, cask_path, etc.
+	// For homebrew_formula: tap_repository, name, formula_path, install_template, etc.
 	CustomSettings map[string]any `json:"custom_settings"`
 
 	// Compiled fields
@@ -121,23 +123,76 @@ func (p *Publisher) Init() error {
 		}
 	}
 
-	// Validate type-specific settings.
-	switch p.Type.FormatParsed {
-	case publishformats.HomebrewCask:
-		if err := p.validateHomebrewCaskSettings(); err != nil {
-			return fmt.Errorf("%s: %v", what, err)
+	return nil
+}
+
+// CompileReleases sets ReleasesCompiled to the releases matching the publisher's paths
+// and validates the settings that depend on them.
+// The ArchsCompiled of the releases must be set.
+func (p *Publisher) CompileReleases(releases []Release) error {
+	what := fmt.Sprintf("publishers: %v", p.Paths)
+
+	for i := range releases {
+		release := &releases[i]
+		// If no release paths specified, match all releases.
+		if p.ReleasePathsCompiled != nil && !p.ReleasePathsCompiled.Match(release.Path) {
+			continue
 		}
+
+		// Validate type-specific settings.
+		switch p.Type.FormatParsed {
+		case publishformats.HomebrewCask, publishformats.HomebrewFormula:
+			// Homebrew downloads the archives from the release.
+			if !release.ReleaseSettings.HasDownloadURL() {
+				return fmt.Errorf("%s: %s: release %q of type %q has no public download URL, set release_settings.download_url_template", what, p.Type.Format, release.Path, release.ReleaseSettings.Type)
+			}
+		}
+		if p.Type.FormatParsed == publishformats.HomebrewCask {
+			if err := p.validateHomebrewCaskSettings(release); err != nil {
+				return fmt.Errorf("%s: %v", what, err)
+			}
+		}
+		if p.Type.FormatParsed == publishformats.HomebrewFormula {
+			if err := p.validateHomebrewFormulaSettings(release); err != nil {
+				return fmt.Errorf("%s: %v", what, err)
+			}
+		}
+
+		p.ReleasesCompiled = append(p.ReleasesCompiled, release)
 	}
 
 	return nil
 }
 
-func (p *Publisher) validateHomebrewCaskSettings() error {
+func (p *Publisher) validateHomebrewCaskSettings(release *Release) error {
 	what := "homebrew_cask"
 
-	// bundle_identifier is required.
-	if _, ok := p.CustomSettings["bundle_identifier"]; !ok {
-		return fmt.Errorf("%s: bundle_identifier is required in custom_settings", what)
+	// bundle_identifier is required to uninstall .pkg archives.
+	if _, ok := p.CustomSettings["bundle_identifier"]; ok {
+		return nil
+	}
+	for _, archPath := range release.ArchsCompiled {
+		if archPath.Arch.Os == nil || archPath.Arch.Os.Goos != "darwin" || !strings.HasSuffix(archPath.Name, ".pkg") {
+			continue
+		}
+		if p.ArchivePathsCompiled != nil && !p.ArchivePathsCompiled.Match(archPath.Path) {
+			continue
+		}
+		return fmt.Errorf("%s: bundle_identifier is required in custom_settings for .pkg archives, found %q", what, archPath.Name)
+	}
+
+	return nil
+}
+
+func (p *Publisher) validateHomebrewFormulaSettings(release *Release) error {
+	what := "homebrew_formula"
+
+	// brew audit requires a desc and a homepage.
+	if s, _ := p.CustomSettings["desc"].(string); s == "" {
+		return fmt.Errorf("%s: desc is required in custom_settings", what)
+	}
+	if s, _ := p.CustomSettings["homepage"].(string); s == "" && release.ReleaseSettings.TypeParsed != releasetypes.GitHub {
+		return fmt.Errorf("%s: homepage is required in custom_settings for release type %q", what, release.ReleaseSettings.Type)
 	}
 
 	return nil
@@ -145,7 +200,7 @@ func (p *Publisher) validateHomebrewCaskSettings() error {
 
 // PublishType represents the type of publisher.
 type PublishType struct {
-	Format string `json:"format"` // github_release, homebrew_cask, _plugin
+	Format string `json:"format"` // github_release, homebrew_cask, homebrew_formula, changelog, _plugin
 
 	FormatParsed publishformats.Format `json:"-"`
 }
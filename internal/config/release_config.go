@@ -16,12 +16,17 @@ package config
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/gohugoio/hugoreleaser/internal/common/matchers"
+	"github.com/gohugoio/hugoreleaser/internal/common/semver"
+	"github.com/gohugoio/hugoreleaser/internal/common/templ"
 	"github.com/gohugoio/hugoreleaser/internal/releases/releasetypes"
 )
 
@@ -74,6 +79,10 @@ func (a *Release) Init() error {
 	return nil
 }
 
+// PrereleaseAuto is the prerelease setting that marks the release as a prerelease
+// when the tag has a pre-release part.
+const PrereleaseAuto = "auto"
+
 type ReleaseSettings struct {
 	Type string `json:"type"`
 
@@ -81,13 +90,488 @@ type ReleaseSettings struct {
 	Repository      string `json:"repository"`
 	RepositoryOwner string `json:"repository_owner"`
 	Draft           bool   `json:"draft"`
-	Prerelease      bool   `json:"prerelease"`
+
+	// One of true, false or auto.
+	// Set to auto to mark the release as a prerelease when the tag has a
+	// pre-release part, e.g. v1.2.0-rc1.
+	Prerelease string `json:"prerelease"`
+
+	// The public URL to download each release file from, used by the Homebrew publishers,
+	// e.g. "https://downloads.example.com/{{ .Project }}/{{ .Tag }}/{{ .Name }}".
+	// Defaults to the GitHub release download URL for the github release type,
+	// and is required for the other release types when used.
+	DownloadURLTemplate string `json:"download_url_template"`
 
 	ReleaseNotesSettings ReleaseNotesSettings `json:"release_notes_settings"`
 
+	// Checksums configures the checksum files created for the release artifacts.
+	Checksums ChecksumsSettings `json:"checksums"`
+
+	// Signing configures signing of the checksum files and, optionally, the archives.
+	Signing SigningSettings `json:"signing"`
+
+	// Provenance configures the in-toto provenance attestation created for the archives.
+	Provenance ProvenanceSettings `json:"provenance"`
+
+	// SBOM configures the SBOM files uploaded with the release, one per format,
+	// covering all the binaries in the release.
+	SBOM SBOMSettings `json:"sbom"`
+
+	// GitTag configures creating and pushing the Git tag before the release is created.
+	GitTag GitTagSettings `json:"git_tag"`
+
+	// Settings for the github release type.
+	GitHub GitHubSettings `json:"github"`
+
+	// Settings for the filesystem release type.
+	FileSystem FileSystemSettings `json:"filesystem"`
+
+	// Settings for the s3 release type.
+	S3 S3Settings `json:"s3"`
+
+	// Settings for the http release type.
+	HTTP HTTPSettings `json:"http"`
+
 	TypeParsed releasetypes.Type `json:"-"`
 }
 
+// IsPrerelease reports whether the release of version should be marked as a prerelease.
+func (r ReleaseSettings) IsPrerelease(version semver.Version) bool {
+	if r.Prerelease == PrereleaseAuto {
+		return version.IsPrerelease()
+	}
+	return r.Prerelease == "true"
+}
+
+// HasDownloadURL reports whether the released files have a public download URL.
+func (r ReleaseSettings) HasDownloadURL() bool {
+	return r.DownloadURLTemplate != "" || r.TypeParsed == releasetypes.GitHub
+}
+
+// DownloadURL returns the public URL to download the release file name for tag from.
+func (r ReleaseSettings) DownloadURL(project, tag string, version semver.Version, name string) (string, error) {
+	if r.DownloadURLTemplate == "" {
+		if r.TypeParsed != releasetypes.GitHub {
+			return "", fmt.Errorf("release type %q has no public download URL, set release_settings.download_url_template", r.Type)
+		}
+		return r.GitHub.DownloadURL(r.RepositoryOwner, r.Repository, tag, name), nil
+	}
+	u, err := templ.Sprintt(r.DownloadURLTemplate, struct {
+		Project string
+		Tag     string
+		Name    string
+		semver.Version
+	}{
+		Project: project,
+		Tag:     tag,
+		Name:    name,
+		Version: version,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute download_url_template: %w", err)
+	}
+	return u, nil
+}
+
+// GitHubSettings configures the github release type, e.g. to use a GitHub Enterprise Server.
+type GitHubSettings struct {
+	// The REST API base URL, e.g. https://github.example.com/api/v3/.
+	// Defaults to https://api.github.com/.
+	APIURL string `json:"api_url"`
+
+	// The upload URL, e.g. https://github.example.com/api/uploads/.
+	// Defaults to api_url.
+	UploadURL string `json:"upload_url"`
+
+	// The web URL used to construct the release download URLs, e.g. https://github.example.com.
+	// Defaults to the scheme and host of api_url if set, else https://github.com.
+	WebURL string `json:"web_url"`
+
+	// The name of the env var holding the token. Defaults to GITHUB_TOKEN.
+	TokenEnv string `json:"token_env"`
+
+	// GitHub App authentication, used instead of the token if app_id is set.
+	AppID          int64 `json:"app_id"`
+	InstallationID int64 `json:"installation_id"`
+
+	// The PEM encoded private key of the GitHub App.
+	PrivateKeyFile string `json:"private_key_file"`
+
+	// The name of the env var holding the private key, used if private_key_file is not set.
+	PrivateKeyEnv string `json:"private_key_env"`
+}
+
+func (g *GitHubSettings) Init() error {
+	what := "github"
+
+	if g.AppID == 0 {
+		if g.InstallationID != 0 || g.PrivateKeyFile != "" || g.PrivateKeyEnv != "" {
+			return fmt.Errorf("%s: app_id is required when using GitHub App authentication", what)
+		}
+	} else {
+		if g.InstallationID == 0 {
+			return fmt.Errorf("%s: installation_id is required when app_id is set", what)
+		}
+		if g.PrivateKeyFile == "" && g.PrivateKeyEnv == "" {
+			return fmt.Errorf("%s: one of private_key_file or private_key_env is required when app_id is set", what)
+		}
+	}
+	for _, u := range []struct {
+		name  string
+		value string
+	}{{"api_url", g.APIURL}, {"upload_url", g.UploadURL}, {"web_url", g.WebURL}} {
+		if u.value == "" {
+			continue
+		}
+		if pu, err := url.Parse(u.value); err != nil || pu.Scheme == "" || pu.Host == "" {
+			return fmt.Errorf("%s: %s must be an absolute URL, got %q", what, u.name, u.value)
+		}
+	}
+
+	if g.TokenEnv == "" {
+		g.TokenEnv = "GITHUB_TOKEN"
+	}
+	if g.UploadURL == "" {
+		g.UploadURL = g.APIURL
+	}
+	if g.WebURL == "" {
+		if g.APIURL != "" {
+			u, _ := url.Parse(g.APIURL)
+			g.WebURL = u.Scheme + "://" + u.Host
+		} else {
+			g.WebURL = "https://github.com"
+		}
+	}
+	g.WebURL = strings.TrimSuffix(g.WebURL, "/")
+
+	return nil
+}
+
+// IsZero is needed to get the shallow merge correct.
+func (g GitHubSettings) IsZero() bool {
+	return g == GitHubSettings{}
+}
+
+// RepositoryURL returns the web URL of the repository.
+func (g GitHubSettings) RepositoryURL(owner, repo string) string {
+	return fmt.Sprintf("%s/%s/%s", g.WebURL, owner, repo)
+}
+
+// DownloadURL returns the URL to download the release file name for tag from.
+func (g GitHubSettings) DownloadURL(owner, repo, tag, name string) string {
+	return fmt.Sprintf("%s/%s/%s/releases/download/%s/%s", g.WebURL, owner, repo, tag, name)
+}
+
+// FileSystemSettings configures the filesystem release type, which copies
+// the release artifacts into <root>/<project>/<tag>.
+type FileSystemSettings struct {
+	// The root directory to release into.
+	// Relative paths are resolved relative to the project directory.
+	Root string `json:"root"`
+
+	// The directory holding local Git checkouts used when publishers update files in a repository,
+	// e.g. a Homebrew tap. Repositories are looked up in <repositories_dir>/<owner>/<repo>.
+	RepositoriesDir string `json:"repositories_dir"`
+}
+
+// IsZero is needed to get the shallow merge correct.
+func (f FileSystemSettings) IsZero() bool {
+	return f.Root == "" && f.RepositoriesDir == ""
+}
+
+// S3MinPartSize is the minimum part size allowed by S3 in multipart uploads.
+const S3MinPartSize = 5 << 20
+
+// S3Settings configures the s3 release type, which uploads the release artifacts
+// to an S3 compatible object storage.
+// The credentials are read from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// the optional AWS_SESSION_TOKEN env vars.
+type S3Settings struct {
+	Bucket string `json:"bucket"`
+
+	// Defaults to the AWS_REGION env var or us-east-1.
+	Region string `json:"region"`
+
+	// Set this to use an S3 compatible service (e.g. MinIO), e.g. http://localhost:9000.
+	// Requests to a custom endpoint use path-style addressing.
+	Endpoint string `json:"endpoint"`
+
+	// The key prefix to upload the files below.
+	// Defaults to "{{ .Project }}/{{ .Tag }}".
+	KeyPrefixTemplate string `json:"key_prefix_template"`
+
+	// If set, the files will also be copied below this prefix, e.g. "{{ .Project }}/latest".
+	LatestKeyPrefixTemplate string `json:"latest_key_prefix_template"`
+
+	// The Cache-Control header to set on the uploaded files.
+	CacheControl string `json:"cache_control"`
+
+	// The Cache-Control header to set on the files copied to the latest prefix.
+	// Defaults to "no-cache".
+	LatestCacheControl string `json:"latest_cache_control"`
+
+	// Files larger than this will be uploaded in parts of this size.
+	// Defaults to 64 MiB, must be at least 5 MiB.
+	PartSize int64 `json:"part_size"`
+}
+
+func (s *S3Settings) Init() error {
+	what := "s3"
+	if s.Bucket == "" {
+		return fmt.Errorf("%s: bucket is required", what)
+	}
+	if s.KeyPrefixTemplate == "" {
+		s.KeyPrefixTemplate = "{{ .Project }}/{{ .Tag }}"
+	}
+	if s.LatestCacheControl == "" {
+		s.LatestCacheControl = "no-cache"
+	}
+	if s.PartSize == 0 {
+		s.PartSize = 64 << 20
+	}
+	if s.PartSize < S3MinPartSize {
+		return fmt.Errorf("%s: part_size must be at least %d bytes", what, S3MinPartSize)
+	}
+	return nil
+}
+
+// IsZero is needed to get the shallow merge correct.
+func (s S3Settings) IsZero() bool {
+	return s == S3Settings{}
+}
+
+// HTTPSettings configures the http release type, which uploads each release artifact
+// to a URL, e.g. a generic Artifactory or a raw Nexus repository.
+type HTTPSettings struct {
+	// The URL to upload each file to, e.g. "https://artifacts.example.com/{{ .Project }}/{{ .Tag }}/{{ .Name }}".
+	URLTemplate string `json:"url_template"`
+
+	// The HTTP method to use, PUT (default) or POST.
+	Method string `json:"method"`
+
+	// Any additional headers to send with each request.
+	Headers map[string]string `json:"headers"`
+
+	// The authentication to use, basic or bearer. Leave empty for none.
+	AuthType string `json:"auth_type"`
+
+	// The names of the env vars holding the credentials for basic auth.
+	UsernameEnv string `json:"username_env"`
+	PasswordEnv string `json:"password_env"`
+
+	// The name of the env var holding the token for bearer auth.
+	TokenEnv string `json:"token_env"`
+
+	// If set, the SHA256 checksum of the file will be sent in this header, e.g. X-Checksum-Sha256.
+	ChecksumHeader string `json:"checksum_header"`
+}
+
+func (h *HTTPSettings) Init() error {
+	what := "http"
+	if h.URLTemplate == "" {
+		return fmt.Errorf("%s: url_template is required", what)
+	}
+	if h.Method == "" {
+		h.Method = http.MethodPut
+	}
+	h.Method = strings.ToUpper(h.Method)
+	if h.Method != http.MethodPut && h.Method != http.MethodPost {
+		return fmt.Errorf("%s: method must be PUT or POST, got %q", what, h.Method)
+	}
+	switch h.AuthType {
+	case "":
+	case "basic":
+		if h.UsernameEnv == "" || h.PasswordEnv == "" {
+			return fmt.Errorf("%s: username_env and password_env are required for basic auth", what)
+		}
+	case "bearer":
+		if h.TokenEnv == "" {
+			return fmt.Errorf("%s: token_env is required for bearer auth", what)
+		}
+	default:
+		return fmt.Errorf("%s: invalid auth_type %q, must be one of [basic bearer]", what, h.AuthType)
+	}
+	return nil
+}
+
+// IsZero is needed to get the shallow merge correct.
+func (h HTTPSettings) IsZero() bool {
+	return h.URLTemplate == "" && h.Method == "" && len(h.Headers) == 0 && h.AuthType == "" &&
+		h.UsernameEnv == "" && h.PasswordEnv == "" && h.TokenEnv == "" && h.ChecksumHeader == ""
+}
+
+// ChecksumAlgorithms is the list of supported checksum algorithms.
+var ChecksumAlgorithms = []string{"blake2b", "sha256", "sha3-256", "sha512"}
+
+// ChecksumsSettings configures the checksum files created for the release artifacts.
+type ChecksumsSettings struct {
+	// The checksum algorithms to use, one or more of sha256 (default), sha512, blake2b and sha3-256.
+	// One checksum file is created per algorithm.
+	Algorithms []string `json:"algorithms"`
+
+	// The name of the checksum file, with .Project, .Tag and .Algorithm available.
+	// The default keeps the <project>_<version>_checksums.txt name for sha256 and
+	// adds an _<algorithm> suffix for the others.
+	NameTemplate string `json:"name_template"`
+
+	// The line format, gnu (default) for "<checksum>  <filename>" or
+	// bsd for "SHA256 (<filename>) = <checksum>".
+	Format string `json:"format"`
+
+	// If set, a <filename>.<algorithm> file holding the checksum line
+	// is also created for each file, e.g. hugo_0.1.0_linux-amd64.tar.gz.sha256.
+	Sidecars bool `json:"sidecars"`
+}
+
+func (c *ChecksumsSettings) Init() error {
+	what := "checksums"
+	if len(c.Algorithms) == 0 {
+		c.Algorithms = []string{"sha256"}
+	}
+	seen := make(map[string]bool)
+	for i, algo := range c.Algorithms {
+		algo = strings.ToLower(algo)
+		if !slices.Contains(ChecksumAlgorithms, algo) {
+			return fmt.Errorf("%s: invalid algorithm %q, must be one of %v", what, algo, ChecksumAlgorithms)
+		}
+		if seen[algo] {
+			return fmt.Errorf("%s: duplicate algorithm %q", what, algo)
+		}
+		seen[algo] = true
+		c.Algorithms[i] = algo
+	}
+	if c.NameTemplate == "" {
+		c.NameTemplate = "{{ .Project }}_{{ .Tag | trimPrefix `v` }}_checksums{{ if ne .Algorithm `sha256` }}_{{ .Algorithm }}{{ end }}.txt"
+	}
+	switch c.Format {
+	case "":
+		c.Format = "gnu"
+	case "gnu", "bsd":
+	default:
+		return fmt.Errorf("%s: invalid format %q, must be one of [bsd gnu]", what, c.Format)
+	}
+	return nil
+}
+
+// IsZero is needed to get the shallow merge correct.
+func (c ChecksumsSettings) IsZero() bool {
+	return len(c.Algorithms) == 0 && c.NameTemplate == "" && c.Format == "" && !c.Sidecars
+}
+
+// SigningSettings configures the detached signatures created for the release artifacts.
+// The signatures are uploaded as release assets next to the signed files.
+type SigningSettings struct {
+	// The signature type, one of ssh (.sig), minisign (.minisig) and openpgp (.asc).
+	// Leave empty to disable signing.
+	Type string `json:"type"`
+
+	// The private key file to sign with.
+	KeyFile string `json:"key_file"`
+
+	// The name of the env var holding the private key, used if key_file is not set.
+	KeyEnv string `json:"key_env"`
+
+	// The name of the env var holding the passphrase of an encrypted private key.
+	PassphraseEnv string `json:"passphrase_env"`
+
+	// The namespace used for ssh signatures. Defaults to "file".
+	Namespace string `json:"namespace"`
+
+	// If set, the archives are also signed, not only the checksum files.
+	Archives bool `json:"archives"`
+}
+
+func (s *SigningSettings) Init() error {
+	what := "signing"
+	switch s.Type {
+	case "":
+		return nil
+	case "minisign", "openpgp", "ssh":
+	default:
+		return fmt.Errorf("%s: invalid type %q, must be one of [minisign openpgp ssh]", what, s.Type)
+	}
+	if s.KeyFile == "" && s.KeyEnv == "" {
+		return fmt.Errorf("%s: one of key_file or key_env is required", what)
+	}
+	return nil
+}
+
+// IsZero is needed to get the shallow merge correct.
+func (s SigningSettings) IsZero() bool {
+	return s == SigningSettings{}
+}
+
+// ProvenanceSettings configures the <project>_<version>.intoto.jsonl file holding
+// an in-toto statement with a SLSA provenance predicate for the archives,
+// wrapped in a DSSE envelope.
+type ProvenanceSettings struct {
+	// Set to true to create the provenance attestation.
+	Generate bool `json:"generate"`
+
+	// The builder ID recorded in the provenance.
+	// Defaults to "https://github.com/gohugoio/hugoreleaser".
+	BuilderID string `json:"builder_id"`
+
+	// The PEM encoded Ed25519, ECDSA or RSA private key to sign the envelope with.
+	KeyFile string `json:"key_file"`
+
+	// The name of the env var holding the private key, used if key_file is not set.
+	KeyEnv string `json:"key_env"`
+
+	// The name of the env var holding the passphrase of an encrypted private key.
+	PassphraseEnv string `json:"passphrase_env"`
+}
+
+func (p *ProvenanceSettings) Init() error {
+	what := "provenance"
+	if !p.Generate {
+		return nil
+	}
+	if p.KeyFile == "" && p.KeyEnv == "" {
+		return fmt.Errorf("%s: one of key_file or key_env is required", what)
+	}
+	return nil
+}
+
+// IsZero is needed to get the shallow merge correct.
+func (p ProvenanceSettings) IsZero() bool {
+	return p == ProvenanceSettings{}
+}
+
+// GitTagSettings configures creating the Git tag locally at -commitish and
+// pushing it to a remote before the release is created,
+// for release targets that don't create the tag.
+type GitTagSettings struct {
+	// Set to true to create and push the tag.
+	Create bool `json:"create"`
+
+	// Set to true to create a signed tag (git tag -s) using the
+	// signing key configured in Git, else an annotated tag is created.
+	Sign bool `json:"sign"`
+
+	// The template for the tag message.
+	// Defaults to "{{ .Project }} {{ .Tag }}".
+	MessageTemplate string `json:"message_template"`
+
+	// The remote to push the tag to. Defaults to origin.
+	Remote string `json:"remote"`
+}
+
+func (g *GitTagSettings) Init() error {
+	if g.MessageTemplate == "" {
+		g.MessageTemplate = "{{ .Project }} {{ .Tag }}"
+	}
+	if g.Remote == "" {
+		g.Remote = "origin"
+	}
+	return nil
+}
+
+// IsZero is needed to get the shallow merge correct.
+func (g GitTagSettings) IsZero() bool {
+	return g == GitTagSettings{}
+}
+
 type ReleaseNotesSettings struct {
 	Generate         bool                `json:"generate"`
 	GenerateOnHost   bool                `json:"generate_on_host"`
@@ -98,9 +582,59 @@ type ReleaseNotesSettings struct {
 	// Can be used to collapse releases with a few number (less than threshold) of changes into one title.
 	ShortThreshold int    `json:"short_threshold"`
 	ShortTitle     string `json:"short_title"`
+
+	// The title of the section listing the breaking changes, which is always listed first.
+	// Defaults to "Breaking Changes".
+	BreakingChangesTitle string `json:"breaking_changes_title"`
+
+	// The glob pattern (see git describe --match) used to find the previous tag,
+	// e.g. tools/v[0-9]* in a monorepo. Defaults to v[0-9]*.
+	TagPattern string `json:"tag_pattern"`
+
+	// If set, only collect commits touching these paths (relative to the repository root).
+	PathFilter []string `json:"path_filter"`
+
+	// Enrich resolves the pull request (number, title and labels) and the author of each change
+	// and the new contributors using the GitHub GraphQL API.
+	// Only supported for the github release type.
+	Enrich bool `json:"enrich"`
+
+	// If set, the release notes are added to this Keep a Changelog formatted file
+	// (relative to the project dir, e.g. CHANGELOG.md) and the result is written to the release dir in dist.
+	// Use the changelog publisher to commit it to the repository.
+	ChangelogFile string `json:"changelog_file"`
+
+	// IntroFromTag uses the message of the annotated tag (-tag) as the release notes intro.
+	IntroFromTag bool `json:"intro_from_tag"`
+
+	// IntroFilenameTemplate is the template for the filename (relative to the project dir)
+	// of a hand-written release notes intro, e.g. notes/{{ .Version }}.md.
+	// The template context has Project, Tag and the version fields, see Template Expansion in the README;
+	// .Version prints the version without the v prefix.
+	IntroFilenameTemplate string `json:"intro_filename_template"`
+}
+
+// HasIntro reports whether a release notes intro source is configured.
+func (g ReleaseNotesSettings) HasIntro() bool {
+	return g.IntroFromTag || g.IntroFilenameTemplate != ""
 }
 
 func (g *ReleaseNotesSettings) Init() error {
+	if g.IntroFromTag && g.IntroFilenameTemplate != "" {
+		return fmt.Errorf("intro_from_tag and intro_filename_template cannot both be set")
+	}
+	if g.HasIntro() && (g.Filename != "" || g.GenerateOnHost) {
+		return fmt.Errorf("intro_from_tag and intro_filename_template cannot be combined with filename or generate_on_host")
+	}
+	if g.ChangelogFile != "" && !g.Generate && g.Filename == "" && !g.HasIntro() {
+		return fmt.Errorf("changelog_file: requires generate, filename or an intro to be set")
+	}
+	if g.TagPattern == "" {
+		g.TagPattern = "v[0-9]*"
+	}
+	if g.BreakingChangesTitle == "" {
+		g.BreakingChangesTitle = "Breaking Changes"
+	}
 	for i := range g.Groups {
 		if err := g.Groups[i].Init(); err != nil {
 			return fmt.Errorf("[%d]: %v", i, err)
@@ -115,13 +649,30 @@ type ReleaseNotesGroup struct {
 	Ignore  bool   `json:"ignore"`
 	Ordinal int    `json:"ordinal"`
 
+	// Conventional Commits types (e.g. feat, fix) and scopes to match.
+	// If more than one of Regexp, Types, Scopes and Labels is set, all must match.
+	Types  []string `json:"types"`
+	Scopes []string `json:"scopes"`
+
+	// Pull request labels to match, any of them. Requires release_notes_settings.enrich.
+	Labels []string `json:"labels"`
+
 	RegexpCompiled matchers.Matcher `json:"-"`
 }
 
 func (g *ReleaseNotesGroup) Init() error {
 	what := "release.release_settings.group"
+	if g.Regexp == "" && len(g.Types) == 0 && len(g.Scopes) == 0 && len(g.Labels) == 0 {
+		return fmt.Errorf("%s: one of regexp, types, scopes or labels must be set", what)
+	}
+
+	for i, t := range g.Types {
+		g.Types[i] = strings.ToLower(t)
+	}
+
 	if g.Regexp == "" {
-		return fmt.Errorf("%s: regexp is not set", what)
+		g.RegexpCompiled = matchers.MatchEverything
+		return nil
 	}
 
 	if !strings.HasPrefix(g.Regexp, "(?") {
@@ -141,6 +692,23 @@ func (g *ReleaseNotesGroup) Init() error {
 	return nil
 }
 
+// Matches reports whether a change with the given Conventional Commits type and scope,
+// subject and pull request labels belongs to this group.
+func (g ReleaseNotesGroup) Matches(typ, scope, subject string, labels []string) bool {
+	if len(g.Types) > 0 && !slices.Contains(g.Types, typ) {
+		return false
+	}
+	if len(g.Scopes) > 0 && !slices.Contains(g.Scopes, scope) {
+		return false
+	}
+	if len(g.Labels) > 0 && !slices.ContainsFunc(labels, func(label string) bool {
+		return slices.ContainsFunc(g.Labels, func(l string) bool { return strings.EqualFold(l, label) })
+	}) {
+		return false
+	}
+	return g.RegexpCompiled.Match(subject)
+}
+
 func (r *ReleaseSettings) Init() error {
 	what := "release.release_settings"
 	if r.Type == "" {
@@ -152,6 +720,57 @@ func (r *ReleaseSettings) Init() error {
 		return fmt.Errorf("%s: %v", what, err)
 	}
 
+	if err := r.GitHub.Init(); err != nil {
+		return fmt.Errorf("%s: %v", what, err)
+	}
+
+	switch r.TypeParsed {
+	case releasetypes.FileSystem:
+		if r.FileSystem.Root == "" {
+			return fmt.Errorf("%s: filesystem.root is required for release type %q", what, r.Type)
+		}
+	case releasetypes.S3:
+		if err := r.S3.Init(); err != nil {
+			return fmt.Errorf("%s: %v", what, err)
+		}
+	case releasetypes.HTTP:
+		if err := r.HTTP.Init(); err != nil {
+			return fmt.Errorf("%s: %v", what, err)
+		}
+	}
+
+	if r.DownloadURLTemplate != "" {
+		if _, err := templ.Parse(r.DownloadURLTemplate); err != nil {
+			return fmt.Errorf("%s: download_url_template: %v", what, err)
+		}
+	}
+
+	switch r.Prerelease {
+	case "", "true", "false", PrereleaseAuto:
+	default:
+		return fmt.Errorf("%s: prerelease must be one of true, false or %s, got %q", what, PrereleaseAuto, r.Prerelease)
+	}
+
+	if err := r.Checksums.Init(); err != nil {
+		return fmt.Errorf("%s: %v", what, err)
+	}
+
+	if err := r.Signing.Init(); err != nil {
+		return fmt.Errorf("%s: %v", what, err)
+	}
+
+	if err := r.Provenance.Init(); err != nil {
+		return fmt.Errorf("%s: %v", what, err)
+	}
+
+	if err := r.SBOM.Init(); err != nil {
+		return fmt.Errorf("%s: %v", what, err)
+	}
+
+	if err := r.GitTag.Init(); err != nil {
+		return fmt.Errorf("%s: %v", what, err)
+	}
+
 	if len(r.ReleaseNotesSettings.Groups) == 0 {
 		// Add a default group matching all.
 		r.ReleaseNotesSettings.Groups = []ReleaseNotesGroup{
@@ -166,6 +785,10 @@ func (r *ReleaseSettings) Init() error {
 		return fmt.Errorf("%s: %v", what, err)
 	}
 
+	if r.ReleaseNotesSettings.Enrich && r.TypeParsed != releasetypes.GitHub {
+		return fmt.Errorf("%s: release_notes_settings.enrich is only supported for release type %q", what, releasetypes.GitHub)
+	}
+
 	return nil
 }
 
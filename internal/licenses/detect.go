@@ -0,0 +1,98 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package licenses
+
+import (
+	"strings"
+)
+
+// headSize is how much of the start of the license text we look at
+// for the title, see contentMatcher.
+const headSize = 300
+
+// The order matters, e.g. the BSD-3-Clause text also matches BSD-2-Clause.
+var contentMatchers = []contentMatcher{
+	// The GNU licenses reference each other in the body, so match the title only.
+	{"AGPL-3.0", true, []string{"gnu affero general public license", "version 3"}},
+	{"LGPL-3.0", true, []string{"gnu lesser general public license", "version 3"}},
+	{"LGPL-2.1", true, []string{"gnu lesser general public license", "version 2.1"}},
+	{"LGPL-2.0", true, []string{"gnu library general public license", "version 2"}},
+	{"GPL-3.0", true, []string{"gnu general public license", "version 3"}},
+	{"GPL-2.0", true, []string{"gnu general public license", "version 2"}},
+	{"MPL-2.0", false, []string{"mozilla public license", "2.0"}},
+	{"EPL-2.0", false, []string{"eclipse public license", "v 2.0"}},
+	{"Apache-2.0", false, []string{"apache license", "version 2.0"}},
+	{"MIT", false, []string{"permission is hereby granted, free of charge"}},
+	{"ISC", false, []string{"permission to use, copy, modify, and/or distribute this software for any purpose"}},
+	{"BSD-3-Clause", false, []string{"redistribution and use in source and binary forms", "neither the name"}},
+	{"BSD-3-Clause", false, []string{"redistribution and use in source and binary forms", "names of its contributors may not be used"}},
+	{"BSD-2-Clause", false, []string{"redistribution and use in source and binary forms"}},
+	{"Unlicense", false, []string{"this is free and unencumbered software released into the public domain"}},
+	{"CC0-1.0", false, []string{"cc0 1.0 universal"}},
+	{"Zlib", false, []string{"this software is provided 'as-is', without any express or implied warranty", "altered source versions must be plainly marked"}},
+}
+
+type contentMatcher struct {
+	typ string
+
+	// Whether to match the phrases against the start of the text only.
+	head    bool
+	phrases []string
+}
+
+func (m contentMatcher) match(text string) bool {
+	if m.head && len(text) > headSize {
+		text = text[:headSize]
+	}
+	for _, p := range m.phrases {
+		if !strings.Contains(text, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filename suffixes used by modules with more than one license file, e.g. LICENSE-MIT.
+var filenameMatchers = []struct {
+	typ    string
+	suffix string
+}{
+	{"Apache-2.0", "apache"},
+	{"Apache-2.0", "apache-2.0"},
+	{"MIT", "mit"},
+	{"BSD-3-Clause", "bsd"},
+}
+
+// Detect returns the SPDX license identifier of the license file with the given
+// name and content, or Unknown.
+func Detect(name string, content []byte) string {
+	text := strings.Join(strings.Fields(strings.ToLower(string(content))), " ")
+	for _, m := range contentMatchers {
+		if m.match(text) {
+			return m.typ
+		}
+	}
+
+	name = strings.ToLower(name)
+	name = strings.TrimSuffix(name, ".md")
+	name = strings.TrimSuffix(name, ".txt")
+	for _, m := range filenameMatchers {
+		if strings.HasSuffix(name, "-"+m.suffix) || strings.HasSuffix(name, "."+m.suffix) || strings.HasSuffix(name, "_"+m.suffix) {
+			return m.typ
+		}
+	}
+
+	return Unknown
+}
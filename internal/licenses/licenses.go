@@ -0,0 +1,262 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package licenses collects the license files of the modules linked into a Go binary.
+package licenses
+
+import (
+	"debug/buildinfo"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// Unknown is the license type used when we could not detect the license.
+const Unknown = "Unknown"
+
+// Options configures where to look for the license files.
+type Options struct {
+	// The Go module cache, usually $GOPATH/pkg/mod.
+	ModCacheDir string
+
+	// The Go root, used to find the license of the standard library.
+	// If empty, the standard library is not included.
+	GoRoot string
+
+	// The directory to resolve relative replace directives against.
+	ProjectDir string
+
+	// Module paths to skip.
+	Ignore []string
+}
+
+// Module is a module linked into the binary and its license files.
+type Module struct {
+	Path    string
+	Version string
+
+	Licenses []License
+}
+
+// Types returns the detected license types, without duplicates.
+func (m Module) Types() []string {
+	var types []string
+	for _, l := range m.Licenses {
+		if l.Type != "" && !slices.Contains(types, l.Type) {
+			types = append(types, l.Type)
+		}
+	}
+	return types
+}
+
+// License is a license or notice file.
+type License struct {
+	// The filename relative to the module root, e.g. LICENSE.md.
+	Name string
+
+	// The detected SPDX license identifier, Unknown if not detected,
+	// or empty for notice files (e.g. NOTICE and PATENTS).
+	Type string
+
+	Content []byte
+}
+
+// Collect reads the build info from the Go binary in filename and returns
+// the modules and their licenses sorted by module path.
+// The main module is not included.
+func Collect(filename string, opts Options) ([]Module, error) {
+	bi, err := buildinfo.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("licenses: failed to read build info from %q: %w", filename, err)
+	}
+
+	var modules []Module
+
+	if opts.GoRoot != "" {
+		m := Module{Path: "std", Version: bi.GoVersion}
+		if m.Licenses, err = readLicenses(opts.GoRoot); err != nil {
+			return nil, err
+		}
+		modules = append(modules, m)
+	}
+
+	for _, dep := range bi.Deps {
+		if slices.Contains(opts.Ignore, dep.Path) {
+			continue
+		}
+		m := Module{Path: dep.Path, Version: dep.Version}
+		source := dep
+		if dep.Replace != nil {
+			source = dep.Replace
+			m.Version = dep.Replace.Version
+		}
+		dir, err := moduleDir(source.Path, source.Version, opts)
+		if err != nil {
+			return nil, err
+		}
+		if m.Licenses, err = readLicenses(dir); err != nil {
+			return nil, err
+		}
+		modules = append(modules, m)
+	}
+
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Path < modules[j].Path })
+
+	return modules, nil
+}
+
+// Check returns an error if any of the modules has no license or a license
+// type matching one of the denied types.
+// A denied type matches as a case insensitive prefix, so AGPL matches AGPL-3.0.
+func Check(modules []Module, deny []string) error {
+	var errs []error
+	for _, m := range modules {
+		types := m.Types()
+		if len(types) == 0 {
+			errs = append(errs, fmt.Errorf("%s %s: no license found", m.Path, m.Version))
+			continue
+		}
+		for _, t := range types {
+			for _, d := range deny {
+				if strings.HasPrefix(strings.ToLower(t), strings.ToLower(d)) {
+					errs = append(errs, fmt.Errorf("%s %s: license %s is not allowed", m.Path, m.Version, t))
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("licenses: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// WriteDir writes the license files to dir, one directory per module path,
+// and returns the filenames written relative to dir, using forward slashes.
+func WriteDir(dir string, modules []Module) ([]string, error) {
+	var filenames []string
+	for _, m := range modules {
+		for _, l := range m.Licenses {
+			name := path.Join(m.Path, l.Name)
+			filename := filepath.Join(dir, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+				return nil, err
+			}
+			if err := os.WriteFile(filename, l.Content, 0o644); err != nil {
+				return nil, err
+			}
+			filenames = append(filenames, name)
+		}
+	}
+	return filenames, nil
+}
+
+// WriteNotice writes all the license files to w as one notice file.
+func WriteNotice(w io.Writer, modules []Module) error {
+	sep := strings.Repeat("=", 80)
+	for i, m := range modules {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		heading := m.Path
+		if m.Version != "" {
+			heading += " " + m.Version
+		}
+		if types := m.Types(); len(types) > 0 {
+			heading += " (" + strings.Join(types, ", ") + ")"
+		}
+		if _, err := fmt.Fprintf(w, "%s\n%s\n%s\n", sep, heading, sep); err != nil {
+			return err
+		}
+		for _, l := range m.Licenses {
+			content := strings.TrimSpace(string(l.Content))
+			if _, err := fmt.Fprintf(w, "\n%s\n\n%s\n", l.Name, content); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func moduleDir(modPath, version string, opts Options) (string, error) {
+	if isLocalPath(modPath) {
+		// A replace directive pointing to a local directory.
+		dir := filepath.FromSlash(modPath)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(opts.ProjectDir, dir)
+		}
+		return dir, nil
+	}
+	escapedPath, err := module.EscapePath(modPath)
+	if err != nil {
+		return "", fmt.Errorf("licenses: %w", err)
+	}
+	escapedVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("licenses: %w", err)
+	}
+	dir := filepath.Join(opts.ModCacheDir, filepath.FromSlash(escapedPath)+"@"+escapedVersion)
+	if _, err := os.Stat(dir); err != nil {
+		return "", fmt.Errorf("licenses: module %s@%s not found in the module cache: %w", modPath, version, err)
+	}
+	return dir, nil
+}
+
+// isLocalPath reports whether the replacement path in a replace directive
+// is a directory, using the same rules as the go command.
+func isLocalPath(modPath string) bool {
+	return modPath == "." || modPath == ".." || strings.HasPrefix(modPath, "./") || strings.HasPrefix(modPath, "../") || filepath.IsAbs(modPath)
+}
+
+var (
+	licenseFilenameRe = regexp.MustCompile(`(?i)^(un)?licen[cs]e|^copying`)
+	noticeFilenameRe  = regexp.MustCompile(`(?i)^(notice|patents)`)
+)
+
+// readLicenses reads the license and notice files in the root of dir.
+func readLicenses(dir string) ([]License, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("licenses: %w", err)
+	}
+	var licenses []License
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		name := e.Name()
+		isLicense := licenseFilenameRe.MatchString(name)
+		if !isLicense && !noticeFilenameRe.MatchString(name) {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("licenses: %w", err)
+		}
+		l := License{Name: name, Content: b}
+		if isLicense {
+			l.Type = Detect(name, b)
+		}
+		licenses = append(licenses, l)
+	}
+	return licenses, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package licenses
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestDetect(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		name    string
+		content string
+		expect  string
+	}{
+		{"LICENSE", "MIT License\n\nCopyright (c) 2020 Foo\n\nPermission is hereby granted, free of charge, to any person", "MIT"},
+		{"LICENSE", "Apache License\n                           Version 2.0, January 2004", "Apache-2.0"},
+		{"LICENSE", "Redistribution and use in source and binary forms, with or without\nmodification ... Neither the name of Google Inc.", "BSD-3-Clause"},
+		{"LICENSE", "Redistribution and use in source and binary forms, with or without\nmodification", "BSD-2-Clause"},
+		{"LICENSE", "Mozilla Public License Version 2.0\n==================================", "MPL-2.0"},
+		{"COPYING", "GNU AFFERO GENERAL PUBLIC LICENSE\n Version 3, 19 November 2007", "AGPL-3.0"},
+		{"COPYING", "GNU LESSER GENERAL PUBLIC LICENSE\n Version 3, 29 June 2007\n ... version 3 of the GNU General Public License", "LGPL-3.0"},
+		// The GPL 3.0 text mentions both the LGPL and the AGPL further down.
+		{"COPYING", "GNU GENERAL PUBLIC LICENSE\n Version 3, 29 June 2007" + strings.Repeat(" lorem ipsum", 100) + " GNU Lesser General Public License ... GNU Affero General Public License", "GPL-3.0"},
+		{"COPYING", "GNU GENERAL PUBLIC LICENSE\n Version 2, June 1991", "GPL-2.0"},
+		{"UNLICENSE", "This is free and unencumbered software released into the public domain.", "Unlicense"},
+		{"LICENSE-MIT", "Copyright 2020 Foo", "MIT"},
+		{"LICENSE.apache.txt", "Copyright 2020 Foo", "Apache-2.0"},
+		{"LICENSE", "All rights reserved.", Unknown},
+	} {
+		c.Assert(Detect(test.name, []byte(test.content)), qt.Equals, test.expect, qt.Commentf("%s: %s", test.name, test.content))
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c := qt.New(t)
+
+	modules := []Module{
+		{Path: "example.com/mit", Version: "v1.0.0", Licenses: []License{{Name: "LICENSE", Type: "MIT"}}},
+		{Path: "example.com/agpl", Version: "v1.0.0", Licenses: []License{{Name: "COPYING", Type: "AGPL-3.0"}}},
+		{Path: "example.com/notice", Version: "v1.0.0", Licenses: []License{{Name: "NOTICE"}}},
+	}
+
+	c.Assert(Check(modules[:1], []string{"agpl"}), qt.IsNil)
+	c.Assert(Check(modules[:2], nil), qt.IsNil)
+	c.Assert(Check(modules[:2], []string{"agpl"}), qt.ErrorMatches, `licenses: example.com/agpl v1.0.0: license AGPL-3.0 is not allowed`)
+	c.Assert(Check(modules[:2], []string{"GPL"}), qt.IsNil)
+	c.Assert(Check(modules, nil), qt.ErrorMatches, `licenses: example.com/notice v1.0.0: no license found`)
+}
+
+func TestCollect(t *testing.T) {
+	c := qt.New(t)
+
+	// The test binary is a Go binary with build info.
+	exe, err := os.Executable()
+	c.Assert(err, qt.IsNil)
+
+	goEnv := func(key string) string {
+		out, err := exec.Command("go", "env", key).Output()
+		c.Assert(err, qt.IsNil)
+		return strings.TrimSpace(string(out))
+	}
+
+	opts := Options{
+		ModCacheDir: goEnv("GOMODCACHE"),
+		GoRoot:      goEnv("GOROOT"),
+	}
+	modules, err := Collect(exe, opts)
+	c.Assert(err, qt.IsNil)
+	c.Assert(Check(modules, []string{"GPL", "AGPL", Unknown}), qt.IsNil)
+
+	m := make(map[string]Module)
+	for _, mod := range modules {
+		m[mod.Path] = mod
+	}
+	c.Assert(m["std"].Version, qt.Equals, runtime.Version())
+	c.Assert(m["std"].Types(), qt.DeepEquals, []string{"BSD-3-Clause"})
+	c.Assert(m["github.com/frankban/quicktest"].Types(), qt.DeepEquals, []string{"MIT"})
+
+	opts.Ignore = []string{"github.com/frankban/quicktest"}
+	modules, err = Collect(exe, opts)
+	c.Assert(err, qt.IsNil)
+	for _, mod := range modules {
+		c.Assert(mod.Path, qt.Not(qt.Equals), "github.com/frankban/quicktest")
+	}
+
+	dir := t.TempDir()
+	names, err := WriteDir(dir, modules)
+	c.Assert(err, qt.IsNil)
+	c.Assert(names, qt.Contains, "std/LICENSE")
+	_, err = os.Stat(filepath.Join(dir, "std", "LICENSE"))
+	c.Assert(err, qt.IsNil)
+
+	var sb strings.Builder
+	c.Assert(WriteNotice(&sb, modules), qt.IsNil)
+	c.Assert(sb.String(), qt.Contains, "std "+runtime.Version()+" (BSD-3-Clause)")
+}
@@ -22,17 +22,21 @@ import (
 )
 
 const (
-	InvalidFormat Format = iota
-	GitHubRelease        // Undrafts the GitHub release
-	HomebrewCask         // Updates Homebrew cask file
-	Plugin               // Plugin is a special format handled by an external tool
+	InvalidFormat   Format = iota
+	GitHubRelease          // Undrafts the GitHub release
+	HomebrewCask           // Updates Homebrew cask file
+	HomebrewFormula        // Updates Homebrew formula file
+	Changelog              // Commits the updated changelog file
+	Plugin                 // Plugin is a special format handled by an external tool
 )
 
 var formatString = map[Format]string{
 	// The string values is what users can specify in the config.
-	GitHubRelease: "github_release",
-	HomebrewCask:  "homebrew_cask",
-	Plugin:        "_plugin",
+	GitHubRelease:   "github_release",
+	HomebrewCask:    "homebrew_cask",
+	HomebrewFormula: "homebrew_formula",
+	Changelog:       "changelog",
+	Plugin:          "_plugin",
 }
 
 var stringFormat = map[string]Format{}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os/exec"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -16,8 +17,10 @@ func CollectChanges(opts Options) (Changes, error) {
 	return c.collect()
 }
 
-// GroupByTitleFunc groups g by title according to the grouping function f.
+// GroupByTitleFunc groups g by title according to the grouping function f,
+// which returns the title and the ordinal of the group.
 // If f returns false, that change item is not included in the result.
+// The groups are sorted by ordinal.
 func GroupByTitleFunc(g Changes, f func(Change) (string, int, bool)) ([]TitleChanges, error) {
 	var ngi []TitleChanges
 	for _, gi := range g {
@@ -33,14 +36,14 @@ func GroupByTitleFunc(g Changes, f func(Change) (string, int, bool)) ([]TitleCha
 			}
 		}
 		if idx == -1 {
-			ngi = append(ngi, TitleChanges{Title: title, ordinal: i + 1})
+			ngi = append(ngi, TitleChanges{Title: title, Ordinal: i})
 			idx = len(ngi) - 1
 		}
 		ngi[idx].Changes = append(ngi[idx].Changes, gi)
 	}
 
 	sort.Slice(ngi, func(i, j int) bool {
-		return ngi[i].ordinal < ngi[j].ordinal
+		return ngi[i].Ordinal < ngi[j].Ordinal
 	})
 
 	return ngi, nil
@@ -50,20 +53,99 @@ func GroupByTitleFunc(g Changes, f func(Change) (string, int, bool)) ([]TitleCha
 // Change represents a git commit.
 type Change struct {
 	// Fetched from git log.
-	Hash    string
-	Author  string
-	Subject string
-	Body    string
+	Hash    string `json:"hash"`
+	Author  string `json:"author"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
 
-	Issues []int
+	Issues []int `json:"issues"`
+
+	// Parsed from the Subject and Body if the commit message follows
+	// the Conventional Commits specification, see https://www.conventionalcommits.org.
+	// Type is lower case, e.g. feat or fix, and empty if not a Conventional Commit.
+	Type        string `json:"type"`
+	Scope       string `json:"scope"`
+	Description string `json:"description"`
+
+	// Breaking is set if the type/scope is followed by a ! or
+	// the body has a BREAKING CHANGE footer.
+	Breaking bool `json:"breaking"`
+
+	// The text of the BREAKING CHANGE footer, if any.
+	BreakingDescription string `json:"breaking_description"`
 
 	// Resolved from GitHub.
-	Username string
+	Username string `json:"username"`
+
+	// Set if Username is a bot account, e.g. dependabot.
+	// Bots are not listed as contributors.
+	Bot bool `json:"bot"`
+
+	// The pull request this change was merged through.
+	// Resolved from GitHub if release_notes_settings.enrich is set, else nil.
+	PullRequest *PullRequest `json:"pull_request"`
+}
+
+// Labels returns the labels of the pull request, if any.
+func (c Change) Labels() []string {
+	if c.PullRequest == nil {
+		return nil
+	}
+	return c.PullRequest.Labels
+}
+
+// PullRequest represents a GitHub pull request.
+type PullRequest struct {
+	Number int      `json:"number"`
+	Title  string   `json:"title"`
+	URL    string   `json:"url"`
+	Author string   `json:"author"`
+	Labels []string `json:"labels"`
+
+	// Set if Author is a bot account, e.g. dependabot.
+	AuthorIsBot bool `json:"author_is_bot"`
 }
 
 // Changes represents a list of git commits.
 type Changes []Change
 
+// Contributor is a user with changes in a release.
+type Contributor struct {
+	Username string
+
+	// The first pull request in the release by this user, if any.
+	PullRequest *PullRequest
+}
+
+// Contributors returns the users with changes in g sorted by username, bots excluded.
+// If newContributors is set, only those users are included.
+func (g Changes) Contributors(newContributors ...string) []Contributor {
+	var contributors []Contributor
+	seen := make(map[string]int)
+	// The changes are listed newest first.
+	for i := len(g) - 1; i >= 0; i-- {
+		change := g[i]
+		if change.Username == "" || change.Bot {
+			continue
+		}
+		if len(newContributors) > 0 && !slices.Contains(newContributors, change.Username) {
+			continue
+		}
+		if idx, found := seen[change.Username]; found {
+			if contributors[idx].PullRequest == nil {
+				contributors[idx].PullRequest = change.PullRequest
+			}
+			continue
+		}
+		seen[change.Username] = len(contributors)
+		contributors = append(contributors, Contributor{Username: change.Username, PullRequest: change.PullRequest})
+	}
+	sort.Slice(contributors, func(i, j int) bool {
+		return strings.ToLower(contributors[i].Username) < strings.ToLower(contributors[j].Username)
+	})
+	return contributors
+}
+
 // Options for collecting changes.
 type Options struct {
 	// Can be nil.
@@ -76,14 +158,24 @@ type Options struct {
 	Tag       string
 	Commitish string
 	RepoPath  string
+
+	// The glob pattern used to find the previous tag if PrevTag is not set.
+	// Defaults to v[0-9]*.
+	TagPattern string
+
+	// If set, only collect commits touching these paths.
+	Paths []string
 }
 
 // TitleChanges represents a list of changes grouped by title.
 type TitleChanges struct {
-	Title   string
-	Changes Changes
+	Title string `json:"title"`
+
+	// The groups are sorted by Ordinal.
+	// It is left out of the JSON for the breaking changes group, which is always first.
+	Ordinal int `json:"ordinal,omitempty"`
 
-	ordinal int
+	Changes Changes `json:"changes"`
 }
 
 type collector struct {
@@ -91,7 +183,7 @@ type collector struct {
 }
 
 func (c *collector) collect() (Changes, error) {
-	log, err := gitLog(c.opts.RepoPath, c.opts.PrevTag, c.opts.Tag, c.opts.Commitish)
+	log, err := gitLog(c.opts.RepoPath, c.opts.PrevTag, c.opts.Tag, c.opts.Commitish, c.opts.TagPattern, c.opts.Paths...)
 	if err != nil {
 		return nil, err
 	}
@@ -126,7 +218,7 @@ func git(repo string, args ...string) (string, error) {
 	return string(out), nil
 }
 
-func gitLog(repo, prevTag, tag, commitish string) (string, error) {
+func gitLog(repo, prevTag, tag, commitish, tagPattern string, paths ...string) (string, error) {
 	var err error
 	if prevTag != "" {
 		exists, err := gitTagExists(repo, prevTag)
@@ -162,13 +254,17 @@ func gitLog(repo, prevTag, tag, commitish string) (string, error) {
 	}
 
 	if from == "" {
-		from, err = gitVersionTagBefore(repo, to)
+		from, err = gitVersionTagBefore(repo, to, tagPattern)
 		if err != nil {
 			return "", err
 		}
 	}
 
 	args := []string{"log", "--pretty=format:%x1e%h%x1f%aE%x1f%s%x1f%b", "--abbrev-commit", from + ".." + to}
+	if len(paths) > 0 {
+		args = append(args, "--")
+		args = append(args, paths...)
+	}
 
 	log, err := git(repo, args...)
 	if err != nil {
@@ -203,12 +299,31 @@ func gitLogToGitInfos(log string) (Changes, error) {
 			gi.Issues = parseIssues(gi.Body)
 		}
 
+		parseConventionalCommit(&gi)
+
 		g = append(g, gi)
 	}
 
 	return g, nil
 }
 
+// TagMessage returns the message of the annotated tag in the Git repository in repo (the current directory if empty),
+// without any signature.
+func TagMessage(repo, tag string) (string, error) {
+	out, err := git(repo, "for-each-ref", "refs/tags/"+tag, "--format=%(objecttype)%00%(contents:subject)%00%(contents:body)")
+	if err != nil {
+		return "", err
+	}
+	parts := strings.SplitN(out, "\x00", 3)
+	if len(parts) != 3 {
+		return "", fmt.Errorf("tag %q does not exist", tag)
+	}
+	if parts[0] != "tag" {
+		return "", fmt.Errorf("tag %q is not an annotated tag", tag)
+	}
+	return strings.TrimSpace(parts[1] + "\n\n" + parts[2]), nil
+}
+
 func gitShort(repo string, args ...string) (output string, err error) {
 	output, err = git(repo, args...)
 	return strings.Replace(strings.Split(output, "\n")[0], "'", "", -1), err
@@ -227,11 +342,19 @@ func gitTagExists(repo, tag string) (bool, error) {
 	return false, nil
 }
 
-func gitVersionTagBefore(repo, ref string) (string, error) {
-	if strings.HasPrefix(ref, "v") {
+func gitVersionTagBefore(repo, ref, tagPattern string) (string, error) {
+	if tagPattern == "" {
+		tagPattern = "v[0-9]*"
+	}
+	// If ref is a tag, start looking from its parent so we don't get the tag itself.
+	isTag, err := gitTagExists(repo, ref)
+	if err != nil {
+		return "", err
+	}
+	if isTag {
 		ref += "^"
 	}
-	return gitShort(repo, "describe", "--tags", "--abbrev=0", "--always", "--match", "v[0-9]*", ref)
+	return gitShort(repo, "describe", "--tags", "--abbrev=0", "--always", "--match", tagPattern, ref)
 }
 
 var issueRe = regexp.MustCompile(`(?i)(?:Updates?|Closes?|Fix.*|See) #(\d+)`)
@@ -248,3 +371,28 @@ func parseIssues(body string) []int {
 	}
 	return i
 }
+
+var (
+	// Only the Conventional Commits types below are recognized (in any case), so
+	// e.g. "releasecmd: Fix foo" is not parsed as such.
+	conventionalCommitRe = regexp.MustCompile(`^(?i:(feat|fix|docs|chore|refactor|perf|test|build|ci|style|revert))(?: ?\(([^()]*)\))?(!)?: (.+)$`)
+	breakingChangeRe     = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: *`)
+)
+
+func parseConventionalCommit(gi *Change) {
+	m := conventionalCommitRe.FindStringSubmatch(gi.Subject)
+	if m == nil {
+		return
+	}
+	gi.Type = strings.ToLower(m[1])
+	gi.Scope = strings.TrimSpace(m[2])
+	gi.Breaking = m[3] == "!"
+	gi.Description = strings.TrimSpace(m[4])
+
+	if loc := breakingChangeRe.FindStringIndex(gi.Body); loc != nil {
+		gi.Breaking = true
+		// The footer value runs until the next blank line.
+		description, _, _ := strings.Cut(gi.Body[loc[1]:], "\n\n")
+		gi.BreakingDescription = strings.TrimSpace(description)
+	}
+}
@@ -16,7 +16,7 @@ func TestGetOps(t *testing.T) {
 	}
 	c := qt.New(t)
 
-	tag, err := gitVersionTagBefore("", "v0.51.0")
+	tag, err := gitVersionTagBefore("", "v0.51.0", "")
 	c.Assert(err, qt.IsNil)
 	c.Assert(tag, qt.Equals, "v0.50.0")
 
@@ -28,7 +28,7 @@ func TestGetOps(t *testing.T) {
 	c.Assert(err, qt.IsNil)
 	c.Assert(exists, qt.Equals, false)
 
-	log, err := gitLog("", "v0.50.0", "v0.51.0", "main")
+	log, err := gitLog("", "v0.50.0", "v0.51.0", "main", "")
 	c.Assert(err, qt.IsNil)
 	c.Assert(log, qt.Contains, "Shuffle chunked builds")
 
@@ -68,10 +68,40 @@ func TestGetVersionTagBefore(t *testing.T) {
 		},
 	} {
 		c.Run(test.about, func(c *qt.C) {
-			tag, err := gitVersionTagBefore("", test.ref)
+			tag, err := gitVersionTagBefore("", test.ref, "")
 			c.Assert(err, qt.IsNil)
 			c.Assert(tag, qt.Equals, test.expect)
 		})
 	}
 
 }
+
+func TestParseConventionalCommit(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		subject string
+		body    string
+		expect  Change
+	}{
+		{"feat: Add foo", "", Change{Type: "feat", Description: "Add foo"}},
+		{"fix(deps)!: Bump bar", "", Change{Type: "fix", Scope: "deps", Description: "Bump bar", Breaking: true}},
+		{"feat(config): Add baz", "Some text.\n\nBREAKING CHANGE: The qux setting is removed.\nUse baz.\n\nCloses #123", Change{Type: "feat", Scope: "config", Description: "Add baz", Breaking: true, BreakingDescription: "The qux setting is removed.\nUse baz."}},
+		{"chore: Foo", "BREAKING-CHANGE: Bar", Change{Type: "chore", Description: "Foo", Breaking: true, BreakingDescription: "Bar"}},
+		{"Add foo", "BREAKING CHANGE: Bar", Change{}},
+		{"revert: Add foo", "", Change{Type: "revert", Description: "Add foo"}},
+		{"releaser: Bump versions for release of 0.51.0", "", Change{}},
+		{"releasecmd: Fix foo", "", Change{}},
+		{"Revert: Add foo", "", Change{Type: "revert", Description: "Add foo"}},
+		{"Fix: Foo", "", Change{Type: "fix", Description: "Foo"}},
+		{"FEAT(Config)!: Foo", "", Change{Type: "feat", Scope: "Config", Description: "Foo", Breaking: true}},
+		{"feat (config): Foo", "", Change{Type: "feat", Scope: "config", Description: "Foo"}},
+		{"feature: Foo", "", Change{}},
+		{"feat:Add foo", "", Change{}},
+	} {
+		gi := Change{Subject: test.subject, Body: test.body}
+		parseConventionalCommit(&gi)
+		test.expect.Subject, test.expect.Body = test.subject, test.body
+		c.Assert(gi, qt.DeepEquals, test.expect, qt.Commentf(test.subject))
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changelog
+
+import (
+	"regexp"
+	"strings"
+)
+
+// keepAChangelogHeader is used when creating a new changelog file.
+const keepAChangelogHeader = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/).
+`
+
+var (
+	// Matches the Unreleased heading and the version headings, e.g. "## [1.2.0] - 2026-10-19",
+	// but not other level 2 headings such as "## Notes".
+	versionHeadingRe = regexp.MustCompile(`^## \[?((?i:unreleased)|v?\d+(?:\.\d+)*(?:[-+][^\]\s]*)?)(?:\]|\s|$)`)
+	linkReferenceRe  = regexp.MustCompile(`^\[[^\]]+\]:\s`)
+	headingRe        = regexp.MustCompile(`^(#{1,5}) `)
+	codeFenceRe      = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+)
+
+// codeFence tracks whether a line is inside a fenced code block.
+type codeFence string
+
+// in reports whether line is part of a fenced code block, the fences included.
+func (f *codeFence) in(line string) bool {
+	m := codeFenceRe.FindStringSubmatch(line)
+	switch {
+	case *f == "":
+		if m != nil {
+			*f = codeFence(m[1])
+			return true
+		}
+		return false
+	case m != nil && m[1][0] == (*f)[0] && len(m[1]) >= len(*f) && strings.TrimSpace(line[len(m[0]):]) == "":
+		*f = ""
+	}
+	return true
+}
+
+// demoteHeadings demotes the Markdown headings in s one level, leaving fenced code blocks as is.
+func demoteHeadings(s string) string {
+	var fence codeFence
+	lines := strings.SplitAfter(s, "\n")
+	for i, line := range lines {
+		if fence.in(line) {
+			continue
+		}
+		lines[i] = headingRe.ReplaceAllString(line, "#$1 ")
+	}
+	return strings.Join(lines, "")
+}
+
+// UpdateKeepAChangelog adds a section for version with the given release notes to the
+// Keep a Changelog (https://keepachangelog.com) formatted content and returns the result.
+// Any existing section for version is replaced, else the new section is added before
+// the latest version, below any Unreleased section.
+// The headings in notes are demoted to fit below the version heading.
+// If content is empty, a new changelog is created.
+func UpdateKeepAChangelog(content, version, date, notes string) string {
+	if strings.TrimSpace(content) == "" {
+		content = keepAChangelogHeader
+	}
+	version = strings.TrimPrefix(version, "v")
+
+	notes = strings.TrimSpace(notes)
+	// Demote the headings in the release notes so they fit below the version heading.
+	notes = demoteHeadings(notes)
+
+	section := "## [" + version + "] - " + date + "\n"
+	if notes != "" {
+		section += "\n" + notes + "\n"
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+
+	// Find the start and end line of the existing section for this version,
+	// or the line to insert the new section before.
+	start, end := -1, -1
+	insertAt := -1
+	var fence codeFence
+	for i, line := range lines {
+		if fence.in(line) {
+			continue
+		}
+		if start != -1 && end == -1 && (strings.HasPrefix(line, "## ") || linkReferenceRe.MatchString(line)) {
+			end = i
+		}
+		m := versionHeadingRe.FindStringSubmatch(line)
+		if m == nil {
+			if insertAt == -1 && linkReferenceRe.MatchString(line) {
+				insertAt = i
+			}
+			continue
+		}
+		v := strings.TrimPrefix(m[1], "v")
+		if v == version {
+			start = i
+			continue
+		}
+		if insertAt == -1 && !strings.EqualFold(v, "unreleased") {
+			insertAt = i
+		}
+	}
+
+	var sb strings.Builder
+	write := func(lines []string) {
+		for _, line := range lines {
+			sb.WriteString(line)
+		}
+	}
+	writeSection := func() {
+		s := sb.String()
+		if s != "" && !strings.HasSuffix(s, "\n") {
+			sb.WriteString("\n")
+		}
+		if s != "" && !strings.HasSuffix(s, "\n\n") {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(section)
+	}
+
+	switch {
+	case start != -1:
+		if end == -1 {
+			end = len(lines)
+		}
+		write(lines[:start])
+		writeSection()
+		if end < len(lines) {
+			sb.WriteString("\n")
+		}
+		write(lines[end:])
+	case insertAt != -1:
+		write(lines[:insertAt])
+		writeSection()
+		sb.WriteString("\n")
+		write(lines[insertAt:])
+	default:
+		write(lines)
+		writeSection()
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changelog
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestUpdateKeepAChangelog(t *testing.T) {
+	c := qt.New(t)
+
+	const notes = "## Features\n\n* Add foo\n\n"
+
+	// New file.
+	got := UpdateKeepAChangelog("", "v1.0.0", "2026-10-19", notes)
+	c.Assert(got, qt.Equals, `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/).
+
+## [1.0.0] - 2026-10-19
+
+### Features
+
+* Add foo
+`)
+
+	// Idempotent.
+	c.Assert(UpdateKeepAChangelog(got, "v1.0.0", "2026-10-19", notes), qt.Equals, got)
+
+	existing := `# Changelog
+
+## [Unreleased]
+
+* Work in progress.
+
+## [1.1.0] - 2026-10-01
+
+### Fixed
+
+* Old notes.
+
+## [1.0.0] - 2026-09-01
+
+* First release.
+
+[unreleased]: https://github.com/bep/foo/compare/v1.1.0...HEAD
+[1.1.0]: https://github.com/bep/foo/compare/v1.0.0...v1.1.0
+`
+
+	// New version is added below Unreleased.
+	c.Assert(UpdateKeepAChangelog(existing, "v1.2.0", "2026-10-19", notes), qt.Equals, `# Changelog
+
+## [Unreleased]
+
+* Work in progress.
+
+## [1.2.0] - 2026-10-19
+
+### Features
+
+* Add foo
+
+## [1.1.0] - 2026-10-01
+
+### Fixed
+
+* Old notes.
+
+## [1.0.0] - 2026-09-01
+
+* First release.
+
+[unreleased]: https://github.com/bep/foo/compare/v1.1.0...HEAD
+[1.1.0]: https://github.com/bep/foo/compare/v1.0.0...v1.1.0
+`)
+
+	// An existing section is replaced, the last one stops at the link references.
+	c.Assert(UpdateKeepAChangelog(existing, "1.0.0", "2026-10-19", notes), qt.Equals, `# Changelog
+
+## [Unreleased]
+
+* Work in progress.
+
+## [1.1.0] - 2026-10-01
+
+### Fixed
+
+* Old notes.
+
+## [1.0.0] - 2026-10-19
+
+### Features
+
+* Add foo
+
+[unreleased]: https://github.com/bep/foo/compare/v1.1.0...HEAD
+[1.1.0]: https://github.com/bep/foo/compare/v1.0.0...v1.1.0
+`)
+
+	// Headings in fenced code blocks are left alone, also when the section is replaced.
+	const fencedNotes = "## Notes\n\n```bash\n# Install\n## Not a version\n```\n\n~~~~\n# Config\n~~~\n## Still config\n~~~~\n"
+	got = UpdateKeepAChangelog("# Changelog\n", "v2.0.0", "2026-10-19", fencedNotes)
+	c.Assert(got, qt.Equals, "# Changelog\n\n## [2.0.0] - 2026-10-19\n\n### Notes\n\n```bash\n# Install\n## Not a version\n```\n\n~~~~\n# Config\n~~~\n## Still config\n~~~~\n")
+	c.Assert(UpdateKeepAChangelog(got, "v2.0.0", "2026-10-19", fencedNotes), qt.Equals, got)
+
+	// Other level 2 headings are not versions.
+	withNotes := "# Changelog\n\n## Notes\n\nSome notes.\n\n## [1.0.0] - 2026-09-01\n\n* First release.\n"
+	c.Assert(UpdateKeepAChangelog(withNotes, "v1.1.0-rc.1", "2026-10-19", ""), qt.Equals, "# Changelog\n\n## Notes\n\nSome notes.\n\n## [1.1.0-rc.1] - 2026-10-19\n\n## [1.0.0] - 2026-09-01\n\n* First release.\n")
+	c.Assert(UpdateKeepAChangelog("# Changelog\n\n## Notes\n", "v1.0.0", "2026-10-19", ""), qt.Equals, "# Changelog\n\n## Notes\n\n## [1.0.0] - 2026-10-19\n")
+
+	// No previous versions.
+	c.Assert(UpdateKeepAChangelog("# Changelog\n\n## [Unreleased]\n", "v0.1.0", "2026-10-19", ""), qt.Equals, "# Changelog\n\n## [Unreleased]\n\n## [0.1.0] - 2026-10-19\n")
+}
@@ -17,7 +17,11 @@ package releases
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/sha512"
 	"encoding/hex"
+	"fmt"
+	"hash"
 	"io"
 	"os"
 	"path/filepath"
@@ -25,13 +29,14 @@ import (
 	"sync"
 
 	"github.com/bep/workers"
+	"golang.org/x/crypto/blake2b"
 )
 
 // ChecksumResult contains the checksum lines and a map of filename to checksum.
 type ChecksumResult struct {
-	// Lines contains the checksum lines in "sha256  filename" format.
+	// Lines contains the checksum lines in the requested format, e.g. "sha256  filename".
 	Lines []string
-	// Checksums maps base filename to its SHA256 checksum.
+	// Checksums maps base filename to its checksum.
 	Checksums map[string]string
 }
 
@@ -39,35 +44,32 @@ type ChecksumResult struct {
 // two spaces and then the base of filename and returns a sorted slice.
 // It also returns a map of base filename -> checksum for programmatic access.
 func CreateChecksumLines(w *workers.Workforce, filenames ...string) (ChecksumResult, error) {
+	return CreateChecksums(w, "sha256", "gnu", filenames...)
+}
+
+// CreateChecksums creates checksum lines for the given files using the given algorithm
+// (see config.ChecksumAlgorithms) and line format (gnu or bsd) and returns them sorted.
+func CreateChecksums(w *workers.Workforce, algorithm, format string, filenames ...string) (ChecksumResult, error) {
 	var mu sync.Mutex
 	var result ChecksumResult
 	result.Checksums = make(map[string]string)
 
 	r, _ := w.Start(context.Background())
 
-	createChecksum := func(filename string) (string, error) {
-		f, err := os.Open(filename)
-		if err != nil {
-			return "", err
-		}
-		defer f.Close()
-		h := sha256.New()
-		if _, err := io.Copy(h, f); err != nil {
-			return "", err
-		}
-		return hex.EncodeToString(h.Sum(nil)), nil
-	}
-
 	for _, filename := range filenames {
 		filename := filename
 		r.Run(func() error {
-			checksum, err := createChecksum(filename)
+			checksum, err := ChecksumFile(algorithm, filename)
 			if err != nil {
 				return err
 			}
 			baseName := filepath.Base(filename)
+			line, err := ChecksumLine(algorithm, format, checksum, baseName)
+			if err != nil {
+				return err
+			}
 			mu.Lock()
-			result.Lines = append(result.Lines, checksum+"  "+baseName)
+			result.Lines = append(result.Lines, line)
 			result.Checksums[baseName] = checksum
 			mu.Unlock()
 
@@ -83,3 +85,60 @@ func CreateChecksumLines(w *workers.Workforce, filenames ...string) (ChecksumRes
 
 	return result, nil
 }
+
+// ChecksumLine formats a checksum line in the given format, gnu or bsd.
+func ChecksumLine(algorithm, format, checksum, name string) (string, error) {
+	switch format {
+	case "gnu":
+		return checksum + "  " + name, nil
+	case "bsd":
+		return fmt.Sprintf("%s (%s) = %s", bsdChecksumTags[algorithm], name, checksum), nil
+	default:
+		return "", fmt.Errorf("invalid checksum format %q", format)
+	}
+}
+
+// The algorithm names as printed by the BSD style tools, e.g. sha256sum --tag.
+var bsdChecksumTags = map[string]string{
+	"blake2b":  "BLAKE2b",
+	"sha256":   "SHA256",
+	"sha3-256": "SHA3-256",
+	"sha512":   "SHA512",
+}
+
+func newChecksumHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case "blake2b":
+		return blake2b.New512(nil)
+	case "sha256":
+		return sha256.New(), nil
+	case "sha3-256":
+		return sha3.New256(), nil
+	case "sha512":
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm %q", algorithm)
+	}
+}
+
+// ChecksumFile returns the checksum of the given file using the given algorithm as lowercase hex digits.
+func ChecksumFile(algorithm, filename string) (string, error) {
+	h, err := newChecksumHash(algorithm)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// SHA256File returns the SHA256 checksum of the given file as lowercase hex digits.
+func SHA256File(filename string) (string, error) {
+	return ChecksumFile("sha256", filename)
+}
@@ -65,3 +65,32 @@ func TestCreateChecksumLines(t *testing.T) {
 	c.Assert(result.Checksums["file9.txt"], qt.Equals, "4e74512f1d8e5016f7a9d9eaebbeedb1549fed5b63428b736eecfea98292d75f")
 	c.Assert(len(result.Checksums), qt.Equals, 10)
 }
+
+func TestCreateChecksums(t *testing.T) {
+	c := qt.New(t)
+
+	w := workers.New(runtime.NumCPU())
+
+	filename := filepath.Join(t.TempDir(), "hello.txt")
+	c.Assert(os.WriteFile(filename, []byte("hello"), 0o644), qt.IsNil)
+
+	for _, test := range []struct {
+		algorithm string
+		format    string
+		expect    string
+	}{
+		{"sha256", "bsd", "SHA256 (hello.txt) = 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		{"sha512", "gnu", "9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca72323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043  hello.txt"},
+		{"blake2b", "bsd", "BLAKE2b (hello.txt) = e4cfa39a3d37be31c59609e807970799caa68a19bfaa15135f165085e01d41a65ba1e1b146aeb6bd0092b49eac214c103ccfa3a365954bbbe52f74a2b3620c94"},
+		{"sha3-256", "gnu", "3338be694f50c5f338814986cdf0686453a888b84f424d792af4b9202398f392  hello.txt"},
+	} {
+		c.Run(test.algorithm, func(c *qt.C) {
+			result, err := CreateChecksums(w, test.algorithm, test.format, filename)
+			c.Assert(err, qt.IsNil)
+			c.Assert(result.Lines, qt.DeepEquals, []string{test.expect})
+		})
+	}
+
+	_, err := CreateChecksums(w, "md5", "gnu", filename)
+	c.Assert(err, qt.ErrorMatches, `unsupported checksum algorithm "md5"`)
+}
@@ -2,21 +2,107 @@ package releases
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"mime"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/gohugoio/hugoreleaser/internal/common/semver"
 	"github.com/gohugoio/hugoreleaser/internal/config"
+	"github.com/gohugoio/hugoreleaser/internal/releases/releasetypes"
 )
 
+// Validate validates the release settings, e.g. that any required credentials are set.
+func Validate(settings config.ReleaseSettings) error {
+	switch settings.TypeParsed {
+	case releasetypes.GitHub:
+		return validateGitHub(settings.GitHub)
+	case releasetypes.FileSystem:
+		return nil
+	case releasetypes.S3:
+		return validateS3()
+	case releasetypes.HTTP:
+		return validateHTTP(settings.HTTP)
+	default:
+		return fmt.Errorf("release: unsupported release type %q", settings.Type)
+	}
+}
+
+// NewClient creates a new Client for the given release settings.
+func NewClient(ctx context.Context, settings config.ReleaseSettings) (Client, error) {
+	if err := Validate(settings); err != nil {
+		return nil, err
+	}
+
+	switch settings.TypeParsed {
+	case releasetypes.GitHub:
+		return newGitHubClient(ctx, settings.GitHub)
+	case releasetypes.FileSystem:
+		return newFileSystemClient(settings.FileSystem)
+	case releasetypes.S3:
+		return newS3Client(settings.S3)
+	case releasetypes.HTTP:
+		return newHTTPClient(settings.HTTP), nil
+	default:
+		panic("unreachable")
+	}
+}
+
 type ReleaseInfo struct {
 	Project   string
 	Tag       string
 	Commitish string
-	Settings  config.ReleaseSettings
+
+	// The parsed -tag, zero if allow_non_semver is set and the tag is not a semantic version.
+	Version  semver.Version
+	Settings config.ReleaseSettings
 }
 
 type Client interface {
 	Release(ctx context.Context, info ReleaseInfo) (int64, error)
 	UploadAssetsFile(ctx context.Context, info ReleaseInfo, f *os.File, releaseID int64) error
+
+	// DeleteRelease deletes the given release and all of its assets.
+	// This is used to roll back a release that failed halfway.
+	DeleteRelease(ctx context.Context, info ReleaseInfo, releaseID int64) error
+}
+
+// FinalizeClient is implemented by clients that have work left to do once all files for a release are uploaded.
+type FinalizeClient interface {
+	Client
+
+	// FinalizeRelease is called once all files for the release are uploaded.
+	FinalizeRelease(ctx context.Context, info ReleaseInfo, releaseID int64) error
+}
+
+// ErrReleaseNotFound is returned (possibly wrapped) from GetReleaseByTag when there's no release for the tag.
+var ErrReleaseNotFound = errors.New("release not found")
+
+// Asset represents a file uploaded to a release.
+type Asset struct {
+	ID   int64
+	Name string
+	Size int64
+
+	// The hex encoded SHA256 checksum of the file, if known by the client.
+	SHA256 string
+
+	// Incomplete is set if the upload of this asset did not finish.
+	Incomplete bool
+}
+
+// AssetsClient extends PublishClient with operations to list and delete release assets.
+// This is used to resume a release that failed halfway.
+type AssetsClient interface {
+	PublishClient
+
+	// ListAssets lists the assets uploaded to the given release.
+	ListAssets(ctx context.Context, info ReleaseInfo, releaseID int64) ([]Asset, error)
+
+	// DeleteAsset deletes the given asset from the given release.
+	DeleteAsset(ctx context.Context, info ReleaseInfo, releaseID int64, asset Asset) error
 }
 
 // PublishClient extends Client with publish-specific operations.
@@ -33,4 +119,65 @@ type PublishClient interface {
 	// UpdateFileInRepo creates or updates a file in a repository.
 	// Returns the commit SHA on success.
 	UpdateFileInRepo(ctx context.Context, owner, repo, path, message string, content []byte) (string, error)
+
+	// UpdateFileInPullRequest commits a file to a branch and opens a pull request for it,
+	// reusing any open pull request for the branch.
+	// Returns the pull request URL and whether it was created.
+	UpdateFileInPullRequest(ctx context.Context, pr PullRequest) (url string, created bool, err error)
+}
+
+// PullRequest describes a file update to be proposed in a pull request.
+type PullRequest struct {
+	// The repository to open the pull request against.
+	Owner string
+	Repo  string
+
+	// The repository to push the branch to, e.g. a fork.
+	// Defaults to Owner and Repo.
+	HeadOwner string
+	HeadRepo  string
+
+	// The branch to commit to, created from the default branch of Owner/Repo if it does not exist.
+	Branch string
+
+	Path    string
+	Message string
+	Content []byte
+
+	Title string
+	Body  string
+}
+
+// Head returns the owner and repository to push the branch to.
+func (pr PullRequest) Head() (owner, repo string) {
+	owner, repo = pr.HeadOwner, pr.HeadRepo
+	if owner == "" {
+		owner = pr.Owner
+	}
+	if repo == "" {
+		repo = pr.Repo
+	}
+	return
+}
+
+// contentType returns the content type to use for the given filename.
+func contentType(filename string) string {
+	switch {
+	case strings.HasSuffix(filename, ".tar.gz"), strings.HasSuffix(filename, ".tgz"):
+		return "application/gzip"
+	case strings.HasSuffix(filename, ".zip"):
+		return "application/zip"
+	case strings.HasSuffix(filename, ".deb"):
+		return "application/vnd.debian.binary-package"
+	case strings.HasSuffix(filename, ".txt"):
+		return "text/plain; charset=utf-8"
+	case strings.HasSuffix(filename, ".md"):
+		return "text/markdown; charset=utf-8"
+	case strings.HasSuffix(filename, ".json"):
+		return "application/json"
+	}
+	if typ := mime.TypeByExtension(filepath.Ext(filename)); typ != "" {
+		return typ
+	}
+	return "application/octet-stream"
 }
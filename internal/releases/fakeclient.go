@@ -35,6 +35,11 @@ func (c *FakeClient) UploadAssetsFile(ctx context.Context, info ReleaseInfo, f *
 	return nil
 }
 
+func (c *FakeClient) DeleteRelease(ctx context.Context, info ReleaseInfo, releaseID int64) error {
+	fmt.Printf("fake: DeleteRelease: releaseID=%d\n", releaseID)
+	return nil
+}
+
 // Ensure FakeClient implements PublishClient.
 var _ PublishClient = &FakeClient{}
 
@@ -53,3 +58,9 @@ func (c *FakeClient) UpdateFileInRepo(ctx context.Context, owner, repo, path, me
 	fmt.Printf("fake: UpdateFileInRepo: owner=%s repo=%s path=%s message=%q\n", owner, repo, path, message)
 	return "fakesha123", nil
 }
+
+func (c *FakeClient) UpdateFileInPullRequest(ctx context.Context, pr PullRequest) (string, bool, error) {
+	headOwner, headRepo := pr.Head()
+	fmt.Printf("fake: UpdateFileInPullRequest: owner=%s repo=%s head=%s/%s branch=%s path=%s title=%q\n", pr.Owner, pr.Repo, headOwner, headRepo, pr.Branch, pr.Path, pr.Title)
+	return fmt.Sprintf("https://github.com/%s/%s/pull/1", pr.Owner, pr.Repo), true, nil
+}
@@ -0,0 +1,489 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bep/helpers/filehelpers"
+	"github.com/gohugoio/hugoreleaser/internal/common/gith"
+	"github.com/gohugoio/hugoreleaser/internal/config"
+)
+
+// fileSystemIndexFilename is the name of the index file stored in the root directory.
+const fileSystemIndexFilename = "releases.json"
+
+// Ensure FileSystemClient implements AssetsClient.
+var _ AssetsClient = &FileSystemClient{}
+
+// FileSystemClient is a client that "releases" to a local directory,
+// storing the files of each release in <root>/<project>/<tag>,
+// with an index of all releases in <root>/releases.json.
+type FileSystemClient struct {
+	root            string
+	repositoriesDir string
+
+	// Protects the index file.
+	mu sync.Mutex
+}
+
+func newFileSystemClient(settings config.FileSystemSettings) (*FileSystemClient, error) {
+	root, err := filepath.Abs(settings.Root)
+	if err != nil {
+		return nil, err
+	}
+	var repositoriesDir string
+	if settings.RepositoriesDir != "" {
+		repositoriesDir, err = filepath.Abs(settings.RepositoriesDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("filesystem: failed to create root directory %q: %w", root, err)
+	}
+	return &FileSystemClient{
+		root:            root,
+		repositoriesDir: repositoriesDir,
+	}, nil
+}
+
+// FileSystemIndex is the content of the releases.json index file.
+type FileSystemIndex struct {
+	Releases     []*FileSystemRelease     `json:"releases"`
+	PullRequests []*FileSystemPullRequest `json:"pull_requests,omitempty"`
+}
+
+// FileSystemRelease is a release stored in the index.
+type FileSystemRelease struct {
+	ID              int64             `json:"id"`
+	Project         string            `json:"project"`
+	Tag             string            `json:"tag"`
+	Commitish       string            `json:"commitish"`
+	Name            string            `json:"name"`
+	Repository      string            `json:"repository"`
+	RepositoryOwner string            `json:"repository_owner"`
+	Draft           bool              `json:"draft"`
+	Prerelease      bool              `json:"prerelease"`
+	Created         time.Time         `json:"created"`
+	Dir             string            `json:"dir"`
+	Assets          []FileSystemAsset `json:"assets"`
+}
+
+// FileSystemPullRequest is a pull request stored in the index.
+// Set State to something else than "open" to mark it as merged or closed.
+type FileSystemPullRequest struct {
+	Number          int       `json:"number"`
+	Repository      string    `json:"repository"`
+	RepositoryOwner string    `json:"repository_owner"`
+	Head            string    `json:"head"`
+	Base            string    `json:"base"`
+	Title           string    `json:"title"`
+	Body            string    `json:"body"`
+	State           string    `json:"state"`
+	URL             string    `json:"url"`
+	Created         time.Time `json:"created"`
+}
+
+// FileSystemAsset is a file stored with a release.
+type FileSystemAsset struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+func (c *FileSystemClient) Release(ctx context.Context, info ReleaseInfo) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index, err := c.readIndex()
+	if err != nil {
+		return 0, err
+	}
+
+	settings := info.Settings
+
+	var nextID int64 = 1
+	for _, r := range index.Releases {
+		if r.Project == info.Project && r.Tag == info.Tag {
+			return 0, fmt.Errorf("filesystem: release for tag %q already exists", info.Tag)
+		}
+		if r.ID >= nextID {
+			nextID = r.ID + 1
+		}
+	}
+
+	dir := filepath.Join(info.Project, info.Tag)
+	if err := os.MkdirAll(filepath.Join(c.root, dir), 0o755); err != nil {
+		return 0, err
+	}
+
+	if filename := settings.ReleaseNotesSettings.Filename; filename != "" {
+		if err := filehelpers.CopyFile(filename, filepath.Join(c.root, dir, "release-notes.md")); err != nil {
+			return 0, fmt.Errorf("filesystem: failed to copy release notes: %w", err)
+		}
+	}
+
+	index.Releases = append(index.Releases, &FileSystemRelease{
+		ID:              nextID,
+		Project:         info.Project,
+		Tag:             info.Tag,
+		Commitish:       info.Commitish,
+		Name:            settings.Name,
+		Repository:      settings.Repository,
+		RepositoryOwner: settings.RepositoryOwner,
+		Draft:           settings.Draft,
+		Prerelease:      settings.IsPrerelease(info.Version),
+		Created:         time.Now().UTC(),
+		Dir:             filepath.ToSlash(dir),
+	})
+
+	if err := c.writeIndex(index); err != nil {
+		return 0, err
+	}
+
+	return nextID, nil
+}
+
+func (c *FileSystemClient) UploadAssetsFile(ctx context.Context, info ReleaseInfo, f *os.File, releaseID int64) error {
+	release, err := c.findRelease(func(r *FileSystemRelease) bool { return r.ID == releaseID })
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Base(f.Name())
+	targetFilename := filepath.Join(c.root, filepath.FromSlash(release.Dir), name)
+
+	// Write to a temporary file first so we never leave a partial file behind.
+	tmp, err := os.CreateTemp(filepath.Dir(targetFilename), "."+name+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, h), f)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), targetFilename); err != nil {
+		return err
+	}
+
+	asset := FileSystemAsset{
+		Name:   name,
+		Size:   size,
+		SHA256: hex.EncodeToString(h.Sum(nil)),
+	}
+
+	return c.updateRelease(releaseID, func(r *FileSystemRelease) error {
+		for i, a := range r.Assets {
+			if a.Name == name {
+				r.Assets[i] = asset
+				return nil
+			}
+		}
+		r.Assets = append(r.Assets, asset)
+		return nil
+	})
+}
+
+func (c *FileSystemClient) GetReleaseByTag(ctx context.Context, owner, repo, tag string) (int64, bool, error) {
+	release, err := c.findRelease(func(r *FileSystemRelease) bool {
+		return r.RepositoryOwner == owner && r.Repository == repo && r.Tag == tag
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	return release.ID, release.Draft, nil
+}
+
+func (c *FileSystemClient) ListAssets(ctx context.Context, info ReleaseInfo, releaseID int64) ([]Asset, error) {
+	release, err := c.findRelease(func(r *FileSystemRelease) bool { return r.ID == releaseID })
+	if err != nil {
+		return nil, err
+	}
+	var assets []Asset
+	for _, a := range release.Assets {
+		fi, err := os.Stat(filepath.Join(c.root, filepath.FromSlash(release.Dir), a.Name))
+		assets = append(assets, Asset{
+			Name:       a.Name,
+			Size:       a.Size,
+			SHA256:     a.SHA256,
+			Incomplete: err != nil || fi.Size() != a.Size,
+		})
+	}
+	return assets, nil
+}
+
+func (c *FileSystemClient) DeleteAsset(ctx context.Context, info ReleaseInfo, releaseID int64, asset Asset) error {
+	return c.updateRelease(releaseID, func(r *FileSystemRelease) error {
+		for i, a := range r.Assets {
+			if a.Name == asset.Name {
+				if err := os.Remove(filepath.Join(c.root, filepath.FromSlash(r.Dir), a.Name)); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+				r.Assets = append(r.Assets[:i], r.Assets[i+1:]...)
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+func (c *FileSystemClient) DeleteRelease(ctx context.Context, info ReleaseInfo, releaseID int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index, err := c.readIndex()
+	if err != nil {
+		return err
+	}
+	for i, r := range index.Releases {
+		if r.ID == releaseID {
+			if err := os.RemoveAll(filepath.Join(c.root, filepath.FromSlash(r.Dir))); err != nil {
+				return err
+			}
+			index.Releases = append(index.Releases[:i], index.Releases[i+1:]...)
+			return c.writeIndex(index)
+		}
+	}
+	return fmt.Errorf("filesystem: release with ID %d not found", releaseID)
+}
+
+func (c *FileSystemClient) PublishRelease(ctx context.Context, owner, repo string, releaseID int64) error {
+	return c.updateRelease(releaseID, func(r *FileSystemRelease) error {
+		r.Draft = false
+		return nil
+	})
+}
+
+// UpdateFileInRepo writes the file into the local Git checkout in <repositories_dir>/<owner>/<repo> and commits it.
+func (c *FileSystemClient) UpdateFileInRepo(ctx context.Context, owner, repo, path, message string, content []byte) (string, error) {
+	repoDir, err := c.repositoryDir(owner, repo)
+	if err != nil {
+		return "", err
+	}
+	return commitFile(repoDir, path, message, content)
+}
+
+// UpdateFileInPullRequest commits the file to a branch in the local Git checkout of the head repository
+// and records the pull request in the index.
+func (c *FileSystemClient) UpdateFileInPullRequest(ctx context.Context, pr PullRequest) (string, bool, error) {
+	baseDir, err := c.repositoryDir(pr.Owner, pr.Repo)
+	if err != nil {
+		return "", false, err
+	}
+	headOwner, headRepo := pr.Head()
+	headDir, err := c.repositoryDir(headOwner, headRepo)
+	if err != nil {
+		return "", false, err
+	}
+
+	base, err := gith.GitLine(baseDir, "symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return "", false, err
+	}
+
+	// Create the branch from the base's default branch if it does not exist.
+	if _, err := gith.Git(headDir, "rev-parse", "--verify", "--quiet", "refs/heads/"+pr.Branch); err != nil {
+		if _, err := gith.Git(headDir, "fetch", "--quiet", baseDir, "refs/heads/"+base); err != nil {
+			return "", false, err
+		}
+		if _, err := gith.Git(headDir, "branch", pr.Branch, "FETCH_HEAD"); err != nil {
+			return "", false, err
+		}
+	}
+
+	// Commit in a temporary worktree unless the branch is already checked out.
+	workDir := headDir
+	if current, _ := gith.GitLine(headDir, "symbolic-ref", "--short", "HEAD"); current != pr.Branch {
+		tempDir, err := os.MkdirTemp("", "hugoreleaser-pr")
+		if err != nil {
+			return "", false, err
+		}
+		defer os.RemoveAll(tempDir)
+		workDir = filepath.Join(tempDir, "worktree")
+		if _, err := gith.Git(headDir, "worktree", "add", "--quiet", workDir, pr.Branch); err != nil {
+			return "", false, err
+		}
+		defer gith.Git(headDir, "worktree", "remove", "--force", workDir)
+	}
+
+	if _, err := commitFile(workDir, pr.Path, pr.Message, pr.Content); err != nil {
+		return "", false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index, err := c.readIndex()
+	if err != nil {
+		return "", false, err
+	}
+
+	head := headOwner + ":" + pr.Branch
+	number := 1
+	for _, p := range index.PullRequests {
+		if p.RepositoryOwner != pr.Owner || p.Repository != pr.Repo {
+			continue
+		}
+		if p.Head == head && p.Base == base && p.State == "open" {
+			return p.URL, false, nil
+		}
+		if p.Number >= number {
+			number = p.Number + 1
+		}
+	}
+
+	url := fmt.Sprintf("file://%s/pull/%d", filepath.ToSlash(baseDir), number)
+	index.PullRequests = append(index.PullRequests, &FileSystemPullRequest{
+		Number:          number,
+		Repository:      pr.Repo,
+		RepositoryOwner: pr.Owner,
+		Head:            head,
+		Base:            base,
+		Title:           pr.Title,
+		Body:            pr.Body,
+		State:           "open",
+		URL:             url,
+		Created:         time.Now().UTC(),
+	})
+
+	if err := c.writeIndex(index); err != nil {
+		return "", false, err
+	}
+
+	return url, true, nil
+}
+
+// repositoryDir returns the local Git checkout for the given repository.
+func (c *FileSystemClient) repositoryDir(owner, repo string) (string, error) {
+	if c.repositoriesDir == "" {
+		return "", errors.New("filesystem: repositories_dir must be set to update files in a repository")
+	}
+	repoDir := filepath.Join(c.repositoriesDir, owner, repo)
+	if _, err := os.Stat(filepath.Join(repoDir, ".git")); err != nil {
+		return "", fmt.Errorf("filesystem: %q is not a Git repository", repoDir)
+	}
+	return repoDir, nil
+}
+
+// commitFile writes the file into the Git work tree in dir and commits it if changed.
+// Returns the SHA of HEAD.
+func commitFile(dir, path, message string, content []byte) (string, error) {
+	filename := filepath.Join(dir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filename, content, 0o644); err != nil {
+		return "", err
+	}
+
+	if _, err := gith.Git(dir, "add", "--", path); err != nil {
+		return "", err
+	}
+
+	status, err := gith.Git(dir, "status", "--porcelain", "--", path)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(status) != "" {
+		if _, err := gith.Git(dir, "commit", "-m", message, "--", path); err != nil {
+			return "", err
+		}
+	}
+
+	return gith.GitLine(dir, "rev-parse", "HEAD")
+}
+
+func (c *FileSystemClient) findRelease(match func(r *FileSystemRelease) bool) (*FileSystemRelease, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index, err := c.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range index.Releases {
+		if match(r) {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("filesystem: %w", ErrReleaseNotFound)
+}
+
+// updateRelease applies update to the release with the given ID and writes the index.
+// The index is left unchanged if update returns an error.
+func (c *FileSystemClient) updateRelease(releaseID int64, update func(r *FileSystemRelease) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index, err := c.readIndex()
+	if err != nil {
+		return err
+	}
+	for _, r := range index.Releases {
+		if r.ID == releaseID {
+			if err := update(r); err != nil {
+				return err
+			}
+			return c.writeIndex(index)
+		}
+	}
+	return fmt.Errorf("filesystem: release with ID %d not found", releaseID)
+}
+
+func (c *FileSystemClient) readIndex() (*FileSystemIndex, error) {
+	index := &FileSystemIndex{}
+	b, err := os.ReadFile(filepath.Join(c.root, fileSystemIndexFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return index, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(b, index); err != nil {
+		return nil, fmt.Errorf("filesystem: failed to read %s: %w", fileSystemIndexFilename, err)
+	}
+	return index, nil
+}
+
+func (c *FileSystemClient) writeIndex(index *FileSystemIndex) error {
+	b, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	filename := filepath.Join(c.root, fileSystemIndexFilename)
+	if err := os.WriteFile(filename+".tmp", b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(filename+".tmp", filename)
+}
@@ -21,52 +21,95 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
-	"github.com/gohugoio/hugoreleaser/internal/releases/releasetypes"
+	"github.com/gohugoio/hugoreleaser/internal/config"
 	"github.com/google/go-github/v45/github"
 	"golang.org/x/oauth2"
 )
 
+// The default env var holding the token.
 const tokenEnvVar = "GITHUB_TOKEN"
 
-// Validate validates the release type.
-func Validate(typ releasetypes.Type) error {
-	if typ != releasetypes.GitHub {
-		return fmt.Errorf("release: only github is supported for now")
+func validateGitHub(settings config.GitHubSettings) error {
+	if settings.AppID != 0 {
+		// The GitHub App settings are validated in config.
+		return nil
 	}
-	token := os.Getenv(tokenEnvVar)
-	if token == "" {
-		return fmt.Errorf("release: missing %q env var", tokenEnvVar)
+	tokenEnv := gitHubTokenEnv(settings)
+	if os.Getenv(tokenEnv) == "" {
+		return fmt.Errorf("release: missing %q env var", tokenEnv)
 	}
 	return nil
 }
 
-func NewClient(ctx context.Context, typ releasetypes.Type) (Client, error) {
-	if err := Validate(typ); err != nil {
-		return nil, err
+func gitHubTokenEnv(settings config.GitHubSettings) string {
+	if settings.TokenEnv != "" {
+		return settings.TokenEnv
 	}
+	return tokenEnvVar
+}
 
-	token := os.Getenv(tokenEnvVar)
+func newGitHubClient(ctx context.Context, settings config.GitHubSettings) (Client, error) {
+	var httpClient *http.Client
 
-	// Set in tests to test the all command.
-	// and when running with the -try flag.
-	if token == "faketoken" {
-		return &FakeClient{}, nil
-	}
+	if settings.AppID != 0 {
+		tokenSource, err := newGitHubAppTokenSource(ctx, settings)
+		if err != nil {
+			return nil, err
+		}
+		httpClient = oauth2.NewClient(ctx, tokenSource)
+	} else {
+		token := os.Getenv(gitHubTokenEnv(settings))
+
+		// Set in tests to test the all command.
+		// and when running with the -try flag.
+		if token == "faketoken" {
+			return &FakeClient{}, nil
+		}
 
-	tokenSource := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
+		tokenSource := oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: token},
+		)
 
-	httpClient := oauth2.NewClient(ctx, tokenSource)
+		httpClient = oauth2.NewClient(ctx, tokenSource)
+	}
+
+	client, graphQLURL, err := newGitHubAPIClient(settings, httpClient)
+	if err != nil {
+		return nil, err
+	}
 
 	return &GitHubClient{
-		client:        github.NewClient(httpClient),
+		client:        client,
+		httpClient:    httpClient,
+		graphQLURL:    graphQLURL,
 		usernameCache: make(map[string]string),
 	}, nil
 }
 
+// newGitHubAPIClient creates a GitHub API client using httpClient and returns it with the GraphQL API URL.
+func newGitHubAPIClient(settings config.GitHubSettings, httpClient *http.Client) (*github.Client, string, error) {
+	if settings.APIURL == "" {
+		return github.NewClient(httpClient), "https://api.github.com/graphql", nil
+	}
+
+	// GitHub Enterprise Server.
+	client, err := github.NewEnterpriseClient(settings.APIURL, settings.UploadURL, httpClient)
+	if err != nil {
+		return nil, "", fmt.Errorf("github: %w", err)
+	}
+	// The GraphQL endpoint is /api/graphql next to the /api/v3 REST API.
+	u := *client.BaseURL
+	if strings.HasSuffix(u.Path, "/api/v3/") {
+		u.Path = strings.TrimSuffix(u.Path, "v3/") + "graphql"
+	} else {
+		u.Path += "graphql"
+	}
+	return client, u.String(), nil
+}
+
 // UploadAssetsFileWithRetries is a wrapper around UploadAssetsFile that retries on temporary errors.
 func UploadAssetsFileWithRetries(ctx context.Context, client Client, info ReleaseInfo, releaseID int64, openFile func() (*os.File, error)) error {
 	return withRetries(func() (error, bool) {
@@ -93,6 +136,10 @@ var _ UsernameResolver = &GitHubClient{}
 type GitHubClient struct {
 	client *github.Client
 
+	// Used for the GraphQL API.
+	httpClient *http.Client
+	graphQLURL string
+
 	usernameCacheMu sync.Mutex
 	usernameCache   map[string]string
 }
@@ -154,7 +201,7 @@ func (c *GitHubClient) Release(ctx context.Context, info ReleaseInfo) (int64, er
 		Name:                 s(settings.Name),
 		Body:                 s(body),
 		Draft:                github.Bool(settings.Draft),
-		Prerelease:           github.Bool(settings.Prerelease),
+		Prerelease:           github.Bool(settings.IsPrerelease(info.Version)),
 		GenerateReleaseNotes: github.Bool(releaseNotesSettings.GenerateOnHost),
 	}
 
@@ -194,12 +241,24 @@ func (c *GitHubClient) UploadAssetsFile(ctx context.Context, info ReleaseInfo, f
 	return TemporaryError{err}
 }
 
+// TemporaryError marks an error as temporary, meaning the operation can be retried.
 type TemporaryError struct {
 	error
 }
 
-// Ensure GitHubClient implements PublishClient.
-var _ PublishClient = &GitHubClient{}
+// Is reports whether target is a TemporaryError, so errors.Is(err, TemporaryError{}) works
+// regardless of the wrapped error.
+func (TemporaryError) Is(target error) bool {
+	_, ok := target.(TemporaryError)
+	return ok
+}
+
+func (e TemporaryError) Unwrap() error {
+	return e.error
+}
+
+// Ensure GitHubClient implements AssetsClient.
+var _ AssetsClient = &GitHubClient{}
 
 func (c *GitHubClient) GetReleaseByTag(ctx context.Context, owner, repo, tag string) (int64, bool, error) {
 	// List releases to find by tag name. We can't use GetReleaseByTag because
@@ -223,7 +282,70 @@ func (c *GitHubClient) GetReleaseByTag(ctx context.Context, owner, repo, tag str
 		opts.Page = resp.NextPage
 	}
 
-	return 0, false, fmt.Errorf("release not found for tag %q", tag)
+	return 0, false, fmt.Errorf("%w for tag %q", ErrReleaseNotFound, tag)
+}
+
+// gitHubReleaseAsset is the subset of the release asset API response we need.
+// It is decoded by hand because go-github does not expose the digest field.
+type gitHubReleaseAsset struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Size  int64  `json:"size"`
+	State string `json:"state"`
+	// Digest is on the form "sha256:<hex>". It may be empty for assets uploaded
+	// before GitHub started computing digests.
+	Digest string `json:"digest"`
+}
+
+func (c *GitHubClient) ListAssets(ctx context.Context, info ReleaseInfo, releaseID int64) ([]Asset, error) {
+	settings := info.Settings
+	var assets []Asset
+	page := 1
+	for {
+		u := fmt.Sprintf("repos/%s/%s/releases/%d/assets?per_page=100&page=%d", settings.RepositoryOwner, settings.Repository, releaseID, page)
+		req, err := c.client.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		var releaseAssets []gitHubReleaseAsset
+		resp, err := c.client.Do(ctx, req, &releaseAssets)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range releaseAssets {
+			var checksum string
+			if strings.HasPrefix(a.Digest, "sha256:") {
+				checksum = strings.TrimPrefix(a.Digest, "sha256:")
+			}
+			assets = append(assets, Asset{
+				ID:     a.ID,
+				Name:   a.Name,
+				Size:   a.Size,
+				SHA256: checksum,
+				// Failed uploads leave an asset behind in the "starter" state.
+				Incomplete: a.State != "uploaded",
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		page = resp.NextPage
+	}
+	return assets, nil
+}
+
+func (c *GitHubClient) DeleteAsset(ctx context.Context, info ReleaseInfo, releaseID int64, asset Asset) error {
+	settings := info.Settings
+	_, err := c.client.Repositories.DeleteReleaseAsset(ctx, settings.RepositoryOwner, settings.Repository, asset.ID)
+	return err
+}
+
+func (c *GitHubClient) DeleteRelease(ctx context.Context, info ReleaseInfo, releaseID int64) error {
+	settings := info.Settings
+	_, err := c.client.Repositories.DeleteRelease(ctx, settings.RepositoryOwner, settings.Repository, releaseID)
+	return err
 }
 
 func (c *GitHubClient) PublishRelease(ctx context.Context, owner, repo string, releaseID int64) error {
@@ -234,11 +356,90 @@ func (c *GitHubClient) PublishRelease(ctx context.Context, owner, repo string, r
 }
 
 func (c *GitHubClient) UpdateFileInRepo(ctx context.Context, owner, repo, path, message string, content []byte) (string, error) {
+	return c.updateFile(ctx, owner, repo, "", path, message, content)
+}
+
+func (c *GitHubClient) UpdateFileInPullRequest(ctx context.Context, pr PullRequest) (string, bool, error) {
+	headOwner, headRepo := pr.Head()
+	head := headOwner + ":" + pr.Branch
+
+	baseRepo, _, err := c.client.Repositories.Get(ctx, pr.Owner, pr.Repo)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get repository %s/%s: %w", pr.Owner, pr.Repo, err)
+	}
+	base := baseRepo.GetDefaultBranch()
+
+	// Create the branch from the base's default branch if it does not exist.
+	_, resp, err := c.client.Git.GetRef(ctx, headOwner, headRepo, "refs/heads/"+pr.Branch)
+	if err != nil {
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return "", false, fmt.Errorf("failed to get branch %s in %s/%s: %w", pr.Branch, headOwner, headRepo, err)
+		}
+		baseRef, _, err := c.client.Git.GetRef(ctx, pr.Owner, pr.Repo, "refs/heads/"+base)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to get branch %s in %s/%s: %w", base, pr.Owner, pr.Repo, err)
+		}
+		_, _, err = c.client.Git.CreateRef(ctx, headOwner, headRepo, &github.Reference{
+			Ref:    github.String("refs/heads/" + pr.Branch),
+			Object: &github.GitObject{SHA: baseRef.Object.SHA},
+		})
+		if err != nil {
+			return "", false, fmt.Errorf("failed to create branch %s in %s/%s: %w", pr.Branch, headOwner, headRepo, err)
+		}
+	}
+
+	if _, err := c.updateFile(ctx, headOwner, headRepo, pr.Branch, pr.Path, pr.Message, pr.Content); err != nil {
+		return "", false, err
+	}
+
+	existing, _, err := c.client.PullRequests.List(ctx, pr.Owner, pr.Repo, &github.PullRequestListOptions{
+		State: "open",
+		Head:  head,
+		Base:  base,
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to list pull requests in %s/%s: %w", pr.Owner, pr.Repo, err)
+	}
+	if len(existing) > 0 {
+		return existing[0].GetHTMLURL(), false, nil
+	}
+
+	created, _, err := c.client.PullRequests.Create(ctx, pr.Owner, pr.Repo, &github.NewPullRequest{
+		Title:               github.String(pr.Title),
+		Body:                github.String(pr.Body),
+		Head:                github.String(head),
+		Base:                github.String(base),
+		MaintainerCanModify: github.Bool(true),
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create pull request in %s/%s: %w", pr.Owner, pr.Repo, err)
+	}
+	return created.GetHTMLURL(), true, nil
+}
+
+// updateFile creates or updates a file in the given branch, or the default branch if empty,
+// and returns the commit SHA.
+// A file with the same content on a branch is left as is, and the branch head commit SHA is returned.
+func (c *GitHubClient) updateFile(ctx context.Context, owner, repo, branch, path, message string, content []byte) (string, error) {
+	var getOpts *github.RepositoryContentGetOptions
+	if branch != "" {
+		getOpts = &github.RepositoryContentGetOptions{Ref: branch}
+	}
+
 	// First try to get existing file to get its SHA.
-	fileContent, _, resp, err := c.client.Repositories.GetContents(ctx, owner, repo, path, nil)
+	fileContent, _, resp, err := c.client.Repositories.GetContents(ctx, owner, repo, path, getOpts)
 	var sha string
 	if err == nil && fileContent != nil {
 		sha = fileContent.GetSHA()
+		if branch != "" {
+			if existing, err := fileContent.GetContent(); err == nil && existing == string(content) {
+				ref, _, err := c.client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+				if err != nil {
+					return "", fmt.Errorf("failed to get branch %s in %s/%s: %w", branch, owner, repo, err)
+				}
+				return ref.GetObject().GetSHA(), nil
+			}
+		}
 	} else if resp != nil && resp.StatusCode != http.StatusNotFound {
 		// Return error only if it's not a 404 (file doesn't exist is OK).
 		return "", fmt.Errorf("failed to get file %s: %w", path, err)
@@ -253,6 +454,9 @@ func (c *GitHubClient) UpdateFileInRepo(ctx context.Context, owner, repo, path,
 		// File exists, update it.
 		opts.SHA = github.String(sha)
 	}
+	if branch != "" {
+		opts.Branch = github.String(branch)
+	}
 
 	result, _, err := c.client.Repositories.CreateFile(ctx, owner, repo, path, opts)
 	if err != nil {
@@ -0,0 +1,147 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releases
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gohugoio/hugoreleaser/internal/config"
+	"github.com/gohugoio/hugoreleaser/internal/releases/signing"
+	"github.com/google/go-github/v45/github"
+	"golang.org/x/oauth2"
+)
+
+// gitHubAppTokenExpiryMargin is how long before the installation token expires it gets refreshed,
+// so a long running upload does not start with a token about to expire.
+const gitHubAppTokenExpiryMargin = 5 * time.Minute
+
+// newGitHubAppTokenSource creates a token source that exchanges a JWT signed with
+// the GitHub App's private key for an installation access token.
+func newGitHubAppTokenSource(ctx context.Context, settings config.GitHubSettings) (oauth2.TokenSource, error) {
+	keyPEM, _, err := signing.ReadKey(settings.PrivateKeyFile, settings.PrivateKeyEnv, "")
+	if err != nil {
+		return nil, fmt.Errorf("github: %w", err)
+	}
+	key, err := parseRSAPrivateKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	appClient, _, err := newGitHubAPIClient(settings, &http.Client{
+		Transport: &gitHubAppTransport{appID: settings.AppID, key: key},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Note that oauth2.NewClient reuses the token until it expires.
+	return &gitHubAppTokenSource{
+		ctx:            ctx,
+		client:         appClient,
+		installationID: settings.InstallationID,
+	}, nil
+}
+
+type gitHubAppTokenSource struct {
+	ctx            context.Context
+	client         *github.Client
+	installationID int64
+}
+
+func (s *gitHubAppTokenSource) Token() (*oauth2.Token, error) {
+	token, _, err := s.client.Apps.CreateInstallationToken(s.ctx, s.installationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("github: failed to create installation token for installation %d: %w", s.installationID, err)
+	}
+	return &oauth2.Token{
+		AccessToken: token.GetToken(),
+		Expiry:      token.GetExpiresAt().Add(-gitHubAppTokenExpiryMargin),
+	}, nil
+}
+
+// gitHubAppTransport authenticates the requests as the GitHub App using a short lived JWT.
+type gitHubAppTransport struct {
+	appID int64
+	key   *rsa.PrivateKey
+}
+
+func (t *gitHubAppTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	jwt, err := gitHubAppJWT(t.appID, t.key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// gitHubAppJWT creates a JWT for the GitHub App signed with RS256.
+// It is valid from a minute before now (to allow for clock drift) and expires
+// after 9 minutes (the maximum allowed is 10).
+func gitHubAppJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	enc := base64.RawURLEncoding
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("github: failed to sign JWT: %w", err)
+	}
+	return unsigned + "." + enc.EncodeToString(sig), nil
+}
+
+// parseRSAPrivateKey parses a PEM encoded PKCS#1 (as downloaded from GitHub) or PKCS#8 RSA private key.
+func parseRSAPrivateKey(b []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("github: failed to decode the GitHub App private key: no PEM block found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("github: failed to parse the GitHub App private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("github: the GitHub App private key must be an RSA key, got %T", key)
+	}
+	return rsaKey, nil
+}
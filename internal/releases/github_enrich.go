@@ -0,0 +1,303 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releases
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gohugoio/hugoreleaser/internal/releases/changelog"
+)
+
+// graphQLBatchSize is the number of commits or users to look up in one GraphQL request.
+const graphQLBatchSize = 50
+
+// ChangesEnricher is implemented by clients that can resolve the username and
+// the pull request of changes in batches.
+type ChangesEnricher interface {
+	// EnrichChanges sets Username and PullRequest on the changes, caching the
+	// commit lookups in cacheFilename.
+	// It returns the usernames of the authors with their first merged pull request in changes.
+	EnrichChanges(ctx context.Context, info ReleaseInfo, changes changelog.Changes, cacheFilename string) ([]string, error)
+}
+
+var _ ChangesEnricher = &GitHubClient{}
+
+// enrichedCommit is the cached information about a commit.
+type enrichedCommit struct {
+	Username    string                 `json:"username"`
+	PullRequest *changelog.PullRequest `json:"pull_request"`
+}
+
+func (c *GitHubClient) EnrichChanges(ctx context.Context, info ReleaseInfo, changes changelog.Changes, cacheFilename string) ([]string, error) {
+	settings := info.Settings
+
+	cache := make(map[string]enrichedCommit)
+	if b, err := os.ReadFile(cacheFilename); err == nil {
+		if err := json.Unmarshal(b, &cache); err != nil {
+			return nil, fmt.Errorf("github: failed to read cache file %q: %w", cacheFilename, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var missing []string
+	for _, change := range changes {
+		if _, found := cache[change.Hash]; !found {
+			missing = append(missing, change.Hash)
+		}
+	}
+
+	for start := 0; start < len(missing); start += graphQLBatchSize {
+		batch := missing[start:min(start+graphQLBatchSize, len(missing))]
+		commits, err := c.lookupCommits(ctx, settings.RepositoryOwner, settings.Repository, batch)
+		if err != nil {
+			return nil, err
+		}
+		for hash, commit := range commits {
+			cache[hash] = commit
+		}
+	}
+
+	if len(missing) > 0 {
+		b, err := json.MarshalIndent(cache, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(cacheFilename), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(cacheFilename, b, 0o644); err != nil {
+			return nil, err
+		}
+	}
+
+	// The merged pull requests per user in this release.
+	pullRequests := make(map[string]map[int]bool)
+	for i, change := range changes {
+		commit := cache[change.Hash]
+		changes[i].Username = commit.Username
+		changes[i].PullRequest = commit.PullRequest
+		changes[i].Bot = isBotLogin(commit.Username)
+		pr := commit.PullRequest
+		if pr == nil {
+			continue
+		}
+		if pr.AuthorIsBot || isBotLogin(pr.Author) {
+			pr.AuthorIsBot = true
+			changes[i].Bot = changes[i].Bot || commit.Username == pr.Author
+			continue
+		}
+		if pr.Author != "" {
+			if pullRequests[pr.Author] == nil {
+				pullRequests[pr.Author] = make(map[int]bool)
+			}
+			pullRequests[pr.Author][pr.Number] = true
+		}
+	}
+
+	var users []string
+	for user := range pullRequests {
+		users = append(users, user)
+	}
+
+	var newContributors []string
+	for start := 0; start < len(users); start += graphQLBatchSize {
+		batch := users[start:min(start+graphQLBatchSize, len(users))]
+		first, err := c.firstMergedPullRequests(ctx, settings.RepositoryOwner, settings.Repository, batch)
+		if err != nil {
+			return nil, err
+		}
+		for user, number := range first {
+			if pullRequests[user][number] {
+				newContributors = append(newContributors, user)
+			}
+		}
+	}
+
+	return newContributors, nil
+}
+
+// lookupCommits resolves the author login and the associated pull request of the given commits.
+// Commits not found in the repository are not included in the result.
+func (c *GitHubClient) lookupCommits(ctx context.Context, owner, repo string, hashes []string) (map[string]enrichedCommit, error) {
+	var sb strings.Builder
+	sb.WriteString("query($owner: String!, $name: String!) {\n  repository(owner: $owner, name: $name) {\n")
+	for i, hash := range hashes {
+		fmt.Fprintf(&sb, "    c%d: object(expression: %s) { ...commitFields }\n", i, strconv.Quote(hash))
+	}
+	sb.WriteString(`  }
+}
+fragment commitFields on Commit {
+  author { user { login } }
+  associatedPullRequests(first: 1) {
+    nodes {
+      number
+      title
+      url
+      author { login __typename }
+      labels(first: 50) { nodes { name } }
+    }
+  }
+}`)
+
+	type login struct {
+		Login    string `json:"login"`
+		TypeName string `json:"__typename"`
+	}
+
+	var data struct {
+		Repository map[string]*struct {
+			Author struct {
+				User *login `json:"user"`
+			} `json:"author"`
+			AssociatedPullRequests struct {
+				Nodes []struct {
+					Number int    `json:"number"`
+					Title  string `json:"title"`
+					URL    string `json:"url"`
+					Author *login `json:"author"`
+					Labels struct {
+						Nodes []struct {
+							Name string `json:"name"`
+						} `json:"nodes"`
+					} `json:"labels"`
+				} `json:"nodes"`
+			} `json:"associatedPullRequests"`
+		} `json:"repository"`
+	}
+
+	if err := c.graphQL(ctx, sb.String(), map[string]any{"owner": owner, "name": repo}, &data); err != nil {
+		return nil, err
+	}
+
+	commits := make(map[string]enrichedCommit)
+	for i, hash := range hashes {
+		node := data.Repository["c"+strconv.Itoa(i)]
+		if node == nil {
+			continue
+		}
+		var commit enrichedCommit
+		if node.Author.User != nil {
+			commit.Username = node.Author.User.Login
+		}
+		if nodes := node.AssociatedPullRequests.Nodes; len(nodes) > 0 {
+			pr := nodes[0]
+			commit.PullRequest = &changelog.PullRequest{
+				Number: pr.Number,
+				Title:  pr.Title,
+				URL:    pr.URL,
+			}
+			if pr.Author != nil {
+				commit.PullRequest.Author = pr.Author.Login
+				commit.PullRequest.AuthorIsBot = pr.Author.TypeName == "Bot"
+			}
+			for _, label := range pr.Labels.Nodes {
+				commit.PullRequest.Labels = append(commit.PullRequest.Labels, label.Name)
+			}
+			if commit.Username == "" {
+				commit.Username = commit.PullRequest.Author
+			}
+		}
+		commits[hash] = commit
+	}
+
+	return commits, nil
+}
+
+// isBotLogin reports whether login looks like a bot account, e.g. dependabot-preview[bot] or renovate-bot.
+// This catches the bots not reported with the Bot type by GitHub, and commits without a pull request.
+func isBotLogin(login string) bool {
+	return strings.HasSuffix(login, "[bot]") || strings.HasSuffix(login, "-bot")
+}
+
+// firstMergedPullRequests returns the number of the first merged pull request in the repository per user.
+// Users without any merged pull requests are not included in the result.
+func (c *GitHubClient) firstMergedPullRequests(ctx context.Context, owner, repo string, users []string) (map[string]int, error) {
+	var sb strings.Builder
+	sb.WriteString("query {\n")
+	for i, user := range users {
+		q := fmt.Sprintf("repo:%s/%s is:pr is:merged author:%s sort:created-asc", owner, repo, user)
+		fmt.Fprintf(&sb, "  u%d: search(query: %s, type: ISSUE, first: 1) { nodes { ... on PullRequest { number } } }\n", i, strconv.Quote(q))
+	}
+	sb.WriteString("}")
+
+	var data map[string]struct {
+		Nodes []struct {
+			Number int `json:"number"`
+		} `json:"nodes"`
+	}
+	if err := c.graphQL(ctx, sb.String(), nil, &data); err != nil {
+		return nil, err
+	}
+
+	first := make(map[string]int)
+	for i, user := range users {
+		if result, found := data["u"+strconv.Itoa(i)]; found && len(result.Nodes) > 0 {
+			first[user] = result.Nodes[0].Number
+		}
+	}
+	return first, nil
+}
+
+// graphQL runs the GraphQL query and decodes the data in the response into v.
+func (c *GitHubClient) graphQL(ctx context.Context, query string, variables map[string]any, v any) error {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.graphQLURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("github: graphql: unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("github: graphql: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		var errs []error
+		for _, e := range result.Errors {
+			errs = append(errs, errors.New(e.Message))
+		}
+		return fmt.Errorf("github: graphql: %w", errors.Join(errs...))
+	}
+
+	return json.Unmarshal(result.Data, v)
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugoreleaser/internal/config"
+	"github.com/gohugoio/hugoreleaser/internal/releases/changelog"
+)
+
+func TestGitHubClientEnrichChanges(t *testing.T) {
+	c := qt.New(t)
+
+	var (
+		numRequests int
+		commitsRe   = regexp.MustCompile(`(c\d+): object\(expression: "(\w+)"\)`)
+		searchRe    = regexp.MustCompile(`(u\d+): search\(query: "[^"]* is:pr is:merged author:(\S+) sort:created-asc"`)
+	)
+
+	pullRequests := map[string]string{
+		"a1": `{"author": {"user": {"login": "bep"}}, "associatedPullRequests": {"nodes": [{"number": 10, "title": "Add foo", "url": "https://github.com/gohugoio/hugo/pull/10", "author": {"login": "bep", "__typename": "User"}, "labels": {"nodes": [{"name": "enhancement"}]}}]}}`,
+		"b2": `{"author": {"user": null}, "associatedPullRequests": {"nodes": [{"number": 11, "title": "Fix bar", "url": "https://github.com/gohugoio/hugo/pull/11", "author": {"login": "newbie", "__typename": "User"}, "labels": {"nodes": [{"name": "bug"}]}}]}}`,
+		"c3": `{"author": {"user": {"login": "dependabot"}}, "associatedPullRequests": {"nodes": [{"number": 12, "title": "Bump baz", "url": "https://github.com/gohugoio/hugo/pull/12", "author": {"login": "dependabot", "__typename": "Bot"}, "labels": {"nodes": []}}]}}`,
+		"d4": `{"author": {"user": {"login": "bep"}}, "associatedPullRequests": {"nodes": []}}`,
+		"f6": `{"author": {"user": {"login": "returning"}}, "associatedPullRequests": {"nodes": [{"number": 13, "title": "Fix qux", "url": "https://github.com/gohugoio/hugo/pull/13", "author": {"login": "returning", "__typename": "User"}, "labels": {"nodes": []}}]}}`,
+		"g7": `{"author": {"user": {"login": "renovate-bot"}}, "associatedPullRequests": {"nodes": []}}`,
+		"h8": `{"author": {"user": null}, "associatedPullRequests": {"nodes": [{"number": 14, "title": "Bump quux", "url": "https://github.com/gohugoio/hugo/pull/14", "author": {"login": "dependabot-preview[bot]", "__typename": "User"}, "labels": {"nodes": []}}]}}`,
+	}
+	// The first merged pull request per user.
+	// returning has only one merged pull request in the search results,
+	// but it is not the one in this release.
+	firstPullRequests := map[string]int{"bep": 1, "newbie": 11, "returning": 5}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests++
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		c.Check(json.NewDecoder(r.Body).Decode(&req), qt.IsNil)
+
+		var fields []string
+		if strings.Contains(req.Query, "repository(") {
+			c.Check(req.Variables, qt.DeepEquals, map[string]any{"owner": "gohugoio", "name": "hugo"})
+			for _, m := range commitsRe.FindAllStringSubmatch(req.Query, -1) {
+				node, found := pullRequests[m[2]]
+				if !found {
+					node = "null"
+				}
+				fields = append(fields, fmt.Sprintf("%q: %s", m[1], node))
+			}
+			fmt.Fprintf(w, `{"data": {"repository": {%s}}}`, strings.Join(fields, ","))
+			return
+		}
+		for _, m := range searchRe.FindAllStringSubmatch(req.Query, -1) {
+			c.Check(m[2], qt.Not(qt.Equals), "dependabot")
+			c.Check(isBotLogin(m[2]), qt.IsFalse)
+			fields = append(fields, fmt.Sprintf(`%q: {"nodes": [{"number": %d}]}`, m[1], firstPullRequests[m[2]]))
+		}
+		fmt.Fprintf(w, `{"data": {%s}}`, strings.Join(fields, ","))
+	}))
+	defer srv.Close()
+
+	client := &GitHubClient{
+		httpClient: srv.Client(),
+		graphQLURL: srv.URL,
+	}
+	info := ReleaseInfo{Settings: config.ReleaseSettings{RepositoryOwner: "gohugoio", Repository: "hugo"}}
+	cacheFilename := filepath.Join(t.TempDir(), "cache.json")
+
+	newChanges := func() changelog.Changes {
+		return changelog.Changes{{Hash: "a1"}, {Hash: "b2"}, {Hash: "c3"}, {Hash: "d4"}, {Hash: "e5"}, {Hash: "f6"}, {Hash: "g7"}, {Hash: "h8"}}
+	}
+
+	changes := newChanges()
+	newContributors, err := client.EnrichChanges(context.Background(), info, changes, cacheFilename)
+	c.Assert(err, qt.IsNil)
+	c.Assert(numRequests, qt.Equals, 2)
+	c.Assert(newContributors, qt.DeepEquals, []string{"newbie"})
+
+	c.Assert(changes[0].Username, qt.Equals, "bep")
+	c.Assert(changes[0].PullRequest, qt.DeepEquals, &changelog.PullRequest{Number: 10, Title: "Add foo", URL: "https://github.com/gohugoio/hugo/pull/10", Author: "bep", Labels: []string{"enhancement"}})
+	c.Assert(changes[1].Username, qt.Equals, "newbie")
+	c.Assert(changes[1].Labels(), qt.DeepEquals, []string{"bug"})
+	c.Assert(changes[2].Username, qt.Equals, "dependabot")
+	c.Assert(changes[2].Bot, qt.IsTrue)
+	c.Assert(changes[2].PullRequest.Author, qt.Equals, "dependabot")
+	c.Assert(changes[2].PullRequest.AuthorIsBot, qt.IsTrue)
+	c.Assert(changes[3].PullRequest, qt.IsNil)
+	c.Assert(changes[4].Username, qt.Equals, "")
+	c.Assert(changes[6].Username, qt.Equals, "renovate-bot")
+	c.Assert(changes[6].Bot, qt.IsTrue)
+	c.Assert(changes[7].Username, qt.Equals, "dependabot-preview[bot]")
+	c.Assert(changes[7].Bot, qt.IsTrue)
+	c.Assert(changes[7].PullRequest.AuthorIsBot, qt.IsTrue)
+
+	contributors := changes.Contributors()
+	c.Assert(len(contributors), qt.Equals, 3)
+	c.Assert(contributors[2].Username, qt.Equals, "returning")
+	c.Assert(contributors[0].Username, qt.Equals, "bep")
+	c.Assert(contributors[0].PullRequest.Number, qt.Equals, 10)
+	c.Assert(changes.Contributors(newContributors...), qt.DeepEquals, []changelog.Contributor{{Username: "newbie", PullRequest: changes[1].PullRequest}})
+
+	// The second time the commits are read from the cache,
+	// except e5, which was not found.
+	changes = newChanges()
+	_, err = client.EnrichChanges(context.Background(), info, changes, cacheFilename)
+	c.Assert(err, qt.IsNil)
+	c.Assert(numRequests, qt.Equals, 4)
+	c.Assert(changes[1].PullRequest.Number, qt.Equals, 11)
+}
+
+func TestGitHubClientGraphQLErrors(t *testing.T) {
+	c := qt.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": null, "errors": [{"message": "Could not resolve to a Repository"}]}`)
+	}))
+	defer srv.Close()
+
+	client := &GitHubClient{
+		httpClient: srv.Client(),
+		graphQLURL: srv.URL,
+	}
+	info := ReleaseInfo{Settings: config.ReleaseSettings{RepositoryOwner: "gohugoio", Repository: "nope"}}
+
+	_, err := client.EnrichChanges(context.Background(), info, changelog.Changes{{Hash: "a1"}}, filepath.Join(t.TempDir(), "cache.json"))
+	c.Assert(err, qt.ErrorMatches, `github: graphql: Could not resolve to a Repository`)
+}
@@ -0,0 +1,316 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releases
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugoreleaser/internal/config"
+)
+
+func TestGitHubClientEnterprise(t *testing.T) {
+	c := qt.New(t)
+	t.Setenv(tokenEnvVar, "sometoken")
+
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		c.Check(r.Header.Get("Authorization"), qt.Equals, "Bearer sometoken")
+		switch r.URL.Path {
+		case "/api/v3/repos/bep/hugo/releases":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 42}`)
+		case "/api/uploads/repos/bep/hugo/releases/42/assets":
+			c.Check(r.URL.Query().Get("name"), qt.Equals, "hugo.tar.gz")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 1}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	settings := config.GitHubSettings{APIURL: srv.URL}
+	c.Assert(settings.Init(), qt.IsNil)
+	c.Assert(settings.UploadURL, qt.Equals, srv.URL)
+	c.Assert(settings.WebURL, qt.Equals, srv.URL)
+	c.Assert(settings.DownloadURL("bep", "hugo", "v1.2.0", "hugo.tar.gz"), qt.Equals, srv.URL+"/bep/hugo/releases/download/v1.2.0/hugo.tar.gz")
+
+	client, err := newGitHubClient(context.Background(), settings)
+	c.Assert(err, qt.IsNil)
+	ghClient := client.(*GitHubClient)
+	c.Assert(ghClient.graphQLURL, qt.Equals, srv.URL+"/api/graphql")
+
+	info := ReleaseInfo{Tag: "v1.2.0", Settings: config.ReleaseSettings{RepositoryOwner: "bep", Repository: "hugo", GitHub: settings}}
+	releaseID, err := client.Release(context.Background(), info)
+	c.Assert(err, qt.IsNil)
+	c.Assert(releaseID, qt.Equals, int64(42))
+
+	filename := filepath.Join(t.TempDir(), "hugo.tar.gz")
+	c.Assert(os.WriteFile(filename, []byte("hugo"), 0o644), qt.IsNil)
+	f, err := os.Open(filename)
+	c.Assert(err, qt.IsNil)
+	defer f.Close()
+	c.Assert(client.UploadAssetsFile(context.Background(), info, f, releaseID), qt.IsNil)
+
+	c.Assert(requests, qt.DeepEquals, []string{
+		"POST /api/v3/repos/bep/hugo/releases",
+		"POST /api/uploads/repos/bep/hugo/releases/42/assets",
+	})
+}
+
+func TestGitHubClientListAssets(t *testing.T) {
+	c := qt.New(t)
+	t.Setenv(tokenEnvVar, "sometoken")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/repos/bep/hugo/releases/42/assets":
+			if r.URL.Query().Get("page") == "2" {
+				fmt.Fprint(w, `[{"id": 3, "name": "hugo.zip", "size": 5, "state": "starter"}]`)
+				return
+			}
+			w.Header().Set("Link", `<http://`+r.Host+`/api/v3/repos/bep/hugo/releases/42/assets?per_page=100&page=2>; rel="next"`)
+			fmt.Fprint(w, `[{"id": 1, "name": "hugo.tar.gz", "size": 4, "state": "uploaded", "digest": "sha256:abc"}, {"id": 2, "name": "hugo.deb", "size": 6, "state": "uploaded"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	settings := config.GitHubSettings{APIURL: srv.URL}
+	c.Assert(settings.Init(), qt.IsNil)
+	client, err := newGitHubClient(context.Background(), settings)
+	c.Assert(err, qt.IsNil)
+
+	info := ReleaseInfo{Tag: "v1.2.0", Settings: config.ReleaseSettings{RepositoryOwner: "bep", Repository: "hugo", GitHub: settings}}
+	assets, err := client.(*GitHubClient).ListAssets(context.Background(), info, 42)
+	c.Assert(err, qt.IsNil)
+	c.Assert(assets, qt.DeepEquals, []Asset{
+		{ID: 1, Name: "hugo.tar.gz", Size: 4, SHA256: "abc"},
+		{ID: 2, Name: "hugo.deb", Size: 6},
+		{ID: 3, Name: "hugo.zip", Size: 5, Incomplete: true},
+	})
+}
+
+func TestGitHubSettingsDefaults(t *testing.T) {
+	c := qt.New(t)
+
+	var settings config.GitHubSettings
+	c.Assert(settings.Init(), qt.IsNil)
+	c.Assert(settings.DownloadURL("bep", "hugo", "v1.2.0", "hugo.tar.gz"), qt.Equals, "https://github.com/bep/hugo/releases/download/v1.2.0/hugo.tar.gz")
+	c.Assert(settings.RepositoryURL("bep", "hugo"), qt.Equals, "https://github.com/bep/hugo")
+
+	settings = config.GitHubSettings{APIURL: "https://github.example.com/api/v3/", WebURL: "https://github.example.com/"}
+	c.Assert(settings.Init(), qt.IsNil)
+	c.Assert(settings.DownloadURL("bep", "hugo", "v1.2.0", "hugo.tar.gz"), qt.Equals, "https://github.example.com/bep/hugo/releases/download/v1.2.0/hugo.tar.gz")
+
+	settings = config.GitHubSettings{APIURL: "github.example.com"}
+	c.Assert(settings.Init(), qt.ErrorMatches, `github: api_url must be an absolute URL, got "github.example.com"`)
+}
+
+func TestGitHubClientApp(t *testing.T) {
+	c := qt.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	c.Assert(err, qt.IsNil)
+	t.Setenv("GITHUB_APP_KEY", string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})))
+	t.Setenv(tokenEnvVar, "")
+
+	var numTokens int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/app/installations/7/access_tokens":
+			jwt, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			c.Check(found, qt.IsTrue)
+			parts := strings.Split(jwt, ".")
+			c.Assert(parts, qt.HasLen, 3)
+			digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+			c.Check(rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig), qt.IsNil)
+			claims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+			c.Check(string(claims), qt.Contains, `"iss":"42"`)
+
+			numTokens++
+			// Expires within the refresh margin, so a new token is created for every request.
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token": "ghs_%d", "expires_at": %q}`, numTokens, time.Now().Add(4*time.Minute).Format(time.RFC3339))
+		case "/api/v3/repos/bep/hugo/releases":
+			c.Check(r.Header.Get("Authorization"), qt.Equals, fmt.Sprintf("Bearer ghs_%d", numTokens))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 1}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	settings := config.GitHubSettings{APIURL: srv.URL, AppID: 42, InstallationID: 7, PrivateKeyEnv: "GITHUB_APP_KEY"}
+	c.Assert(settings.Init(), qt.IsNil)
+	c.Assert(validateGitHub(settings), qt.IsNil)
+
+	client, err := newGitHubClient(context.Background(), settings)
+	c.Assert(err, qt.IsNil)
+
+	info := ReleaseInfo{Tag: "v1.2.0", Settings: config.ReleaseSettings{RepositoryOwner: "bep", Repository: "hugo", GitHub: settings}}
+	for range 2 {
+		_, err = client.Release(context.Background(), info)
+		c.Assert(err, qt.IsNil)
+	}
+	c.Assert(numTokens, qt.Equals, 2)
+}
+
+func TestGitHubSettingsApp(t *testing.T) {
+	c := qt.New(t)
+
+	settings := config.GitHubSettings{AppID: 42}
+	c.Assert(settings.Init(), qt.ErrorMatches, `github: installation_id is required when app_id is set`)
+	settings = config.GitHubSettings{AppID: 42, InstallationID: 7}
+	c.Assert(settings.Init(), qt.ErrorMatches, `github: one of private_key_file or private_key_env is required when app_id is set`)
+	settings = config.GitHubSettings{InstallationID: 7}
+	c.Assert(settings.Init(), qt.ErrorMatches, `github: app_id is required when using GitHub App authentication`)
+}
+
+func TestGitHubClientPullRequest(t *testing.T) {
+	c := qt.New(t)
+	t.Setenv(tokenEnvVar, "sometoken")
+
+	var (
+		requests    []string
+		branchSHA   string
+		fileContent string
+		pullURL     string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v3/repos/Homebrew/homebrew-core":
+			fmt.Fprint(w, `{"default_branch": "master"}`)
+		case "GET /api/v3/repos/bep/homebrew-core/git/ref/heads/hugo-1.2.0":
+			if branchSHA == "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprintf(w, `{"ref": "refs/heads/hugo-1.2.0", "object": {"sha": %q}}`, branchSHA)
+		case "GET /api/v3/repos/Homebrew/homebrew-core/git/ref/heads/master":
+			fmt.Fprint(w, `{"ref": "refs/heads/master", "object": {"sha": "abc"}}`)
+		case "POST /api/v3/repos/bep/homebrew-core/git/refs":
+			b, _ := io.ReadAll(r.Body)
+			c.Check(string(b), qt.Contains, `"sha":"abc"`)
+			branchSHA = "abc"
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"ref": "refs/heads/hugo-1.2.0", "object": {"sha": "abc"}}`)
+		case "GET /api/v3/repos/bep/homebrew-core/contents/Casks/hugo.rb":
+			c.Check(r.URL.Query().Get("ref"), qt.Equals, "hugo-1.2.0")
+			if fileContent == "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprintf(w, `{"type": "file", "sha": "blob", "encoding": "base64", "content": %q}`, base64.StdEncoding.EncodeToString([]byte(fileContent)))
+		case "PUT /api/v3/repos/bep/homebrew-core/contents/Casks/hugo.rb":
+			b, _ := io.ReadAll(r.Body)
+			c.Check(string(b), qt.Contains, `"branch":"hugo-1.2.0"`)
+			fileContent = "cask"
+			branchSHA = "def"
+			fmt.Fprint(w, `{"commit": {"sha": "def"}}`)
+		case "GET /api/v3/repos/Homebrew/homebrew-core/pulls":
+			c.Check(r.URL.Query().Get("head"), qt.Equals, "bep:hugo-1.2.0")
+			c.Check(r.URL.Query().Get("base"), qt.Equals, "master")
+			if pullURL == "" {
+				fmt.Fprint(w, `[]`)
+				return
+			}
+			fmt.Fprintf(w, `[{"html_url": %q}]`, pullURL)
+		case "POST /api/v3/repos/Homebrew/homebrew-core/pulls":
+			b, _ := io.ReadAll(r.Body)
+			c.Check(string(b), qt.Contains, `"head":"bep:hugo-1.2.0"`)
+			c.Check(string(b), qt.Contains, `"title":"hugo 1.2.0"`)
+			pullURL = "https://github.com/Homebrew/homebrew-core/pull/1"
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"html_url": %q}`, pullURL)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	settings := config.GitHubSettings{APIURL: srv.URL}
+	c.Assert(settings.Init(), qt.IsNil)
+	client, err := newGitHubClient(context.Background(), settings)
+	c.Assert(err, qt.IsNil)
+
+	pr := PullRequest{
+		Owner:     "Homebrew",
+		Repo:      "homebrew-core",
+		HeadOwner: "bep",
+		Branch:    "hugo-1.2.0",
+		Path:      "Casks/hugo.rb",
+		Message:   "Update hugo to v1.2.0",
+		Content:   []byte("cask"),
+		Title:     "hugo 1.2.0",
+	}
+
+	url, created, err := client.(*GitHubClient).UpdateFileInPullRequest(context.Background(), pr)
+	c.Assert(err, qt.IsNil)
+	c.Assert(created, qt.IsTrue)
+	c.Assert(url, qt.Equals, "https://github.com/Homebrew/homebrew-core/pull/1")
+	c.Assert(requests, qt.DeepEquals, []string{
+		"GET /api/v3/repos/Homebrew/homebrew-core",
+		"GET /api/v3/repos/bep/homebrew-core/git/ref/heads/hugo-1.2.0",
+		"GET /api/v3/repos/Homebrew/homebrew-core/git/ref/heads/master",
+		"POST /api/v3/repos/bep/homebrew-core/git/refs",
+		"GET /api/v3/repos/bep/homebrew-core/contents/Casks/hugo.rb",
+		"PUT /api/v3/repos/bep/homebrew-core/contents/Casks/hugo.rb",
+		"GET /api/v3/repos/Homebrew/homebrew-core/pulls",
+		"POST /api/v3/repos/Homebrew/homebrew-core/pulls",
+	})
+
+	// Running it again reuses the branch, commit and pull request.
+	requests = nil
+	url, created, err = client.(*GitHubClient).UpdateFileInPullRequest(context.Background(), pr)
+	c.Assert(err, qt.IsNil)
+	c.Assert(created, qt.IsFalse)
+	c.Assert(url, qt.Equals, "https://github.com/Homebrew/homebrew-core/pull/1")
+	c.Assert(requests, qt.DeepEquals, []string{
+		"GET /api/v3/repos/Homebrew/homebrew-core",
+		"GET /api/v3/repos/bep/homebrew-core/git/ref/heads/hugo-1.2.0",
+		"GET /api/v3/repos/bep/homebrew-core/contents/Casks/hugo.rb",
+		"GET /api/v3/repos/bep/homebrew-core/git/ref/heads/hugo-1.2.0",
+		"GET /api/v3/repos/Homebrew/homebrew-core/pulls",
+	})
+
+	// An unchanged file returns the branch head commit.
+	sha, err := client.(*GitHubClient).updateFile(context.Background(), "bep", "homebrew-core", "hugo-1.2.0", pr.Path, pr.Message, pr.Content)
+	c.Assert(err, qt.IsNil)
+	c.Assert(sha, qt.Equals, "def")
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/gohugoio/hugoreleaser/internal/common/semver"
+	"github.com/gohugoio/hugoreleaser/internal/common/templ"
+	"github.com/gohugoio/hugoreleaser/internal/config"
+)
+
+// There's no release to create when uploading to a plain URL, so we use the same ID for all of them.
+const httpReleaseID = 1
+
+func validateHTTP(settings config.HTTPSettings) error {
+	var envVars []string
+	switch settings.AuthType {
+	case "basic":
+		envVars = []string{settings.UsernameEnv, settings.PasswordEnv}
+	case "bearer":
+		envVars = []string{settings.TokenEnv}
+	}
+	for _, k := range envVars {
+		if os.Getenv(k) == "" {
+			return fmt.Errorf("release: missing %q env var", k)
+		}
+	}
+	return nil
+}
+
+func newHTTPClient(settings config.HTTPSettings) *HTTPClient {
+	return &HTTPClient{
+		httpClient: http.DefaultClient,
+		settings:   settings,
+	}
+}
+
+// HTTPClient uploads each release artifact to a templated URL.
+type HTTPClient struct {
+	httpClient *http.Client
+	settings   config.HTTPSettings
+
+	// The URLs uploaded to by this client, used in DeleteRelease.
+	mu       sync.Mutex
+	uploaded []string
+}
+
+// Release uploads the release notes, if any.
+func (c *HTTPClient) Release(ctx context.Context, info ReleaseInfo) (int64, error) {
+	if filename := info.Settings.ReleaseNotesSettings.Filename; filename != "" {
+		err := withRetries(func() (error, bool) {
+			f, err := os.Open(filename)
+			if err != nil {
+				return err, false
+			}
+			defer f.Close()
+			err = c.upload(ctx, info, f, "release-notes.md")
+			return err, errors.Is(err, TemporaryError{})
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return httpReleaseID, nil
+}
+
+func (c *HTTPClient) UploadAssetsFile(ctx context.Context, info ReleaseInfo, f *os.File, releaseID int64) error {
+	return c.upload(ctx, info, f, filepath.Base(f.Name()))
+}
+
+// DeleteRelease sends a DELETE request to each URL uploaded to by this client.
+func (c *HTTPClient) DeleteRelease(ctx context.Context, info ReleaseInfo, releaseID int64) error {
+	c.mu.Lock()
+	urls := c.uploaded
+	c.uploaded = nil
+	c.mu.Unlock()
+
+	for _, u := range urls {
+		req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+		if err != nil {
+			return err
+		}
+		c.setAuth(req)
+		if err := c.do(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *HTTPClient) upload(ctx context.Context, info ReleaseInfo, f *os.File, name string) error {
+	settings := c.settings
+
+	u, err := templ.Sprintt(settings.URLTemplate, struct {
+		Project string
+		Tag     string
+		Name    string
+		semver.Version
+	}{
+		Project: info.Project,
+		Tag:     info.Tag,
+		Name:    name,
+		Version: info.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("http: failed to execute URL template: %w", err)
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	var checksum string
+	if settings.ChecksumHeader != "" {
+		h := sha256.New()
+		if _, err := io.Copy(h, f); err != nil {
+			return err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		checksum = hex.EncodeToString(h.Sum(nil))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, settings.Method, u, io.NopCloser(f))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = fi.Size()
+	if fi.Size() == 0 {
+		req.Body = http.NoBody
+	}
+
+	req.Header.Set("Content-Type", contentType(name))
+	for k, v := range settings.Headers {
+		req.Header.Set(k, v)
+	}
+	if checksum != "" {
+		req.Header.Set(settings.ChecksumHeader, checksum)
+	}
+
+	c.setAuth(req)
+
+	if err := c.do(req); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.uploaded = append(c.uploaded, u)
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *HTTPClient) setAuth(req *http.Request) {
+	settings := c.settings
+	switch settings.AuthType {
+	case "basic":
+		req.SetBasicAuth(os.Getenv(settings.UsernameEnv), os.Getenv(settings.PasswordEnv))
+	case "bearer":
+		req.Header.Set("Authorization", "Bearer "+os.Getenv(settings.TokenEnv))
+	}
+}
+
+func (c *HTTPClient) do(req *http.Request) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return TemporaryError{err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err := fmt.Errorf("http: %s %s: unexpected status code %d: %s", req.Method, req.URL, resp.StatusCode, b)
+		if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+			return TemporaryError{err}
+		}
+		return err
+	}
+
+	return nil
+}
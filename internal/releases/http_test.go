@@ -0,0 +1,117 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releases
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugoreleaser/internal/config"
+)
+
+func TestHTTPClientUpload(t *testing.T) {
+	c := qt.New(t)
+
+	var (
+		numRequests int
+		uploaded    = make(map[string]string)
+		reqHeaders  http.Header
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests++
+		if numRequests == 1 {
+			// Fail the first request to test the retries.
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "admin" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		uploaded[r.Method+" "+r.URL.Path] = string(b)
+		reqHeaders = r.Header
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	c.Setenv("ARTIFACTS_USER", "admin")
+	c.Setenv("ARTIFACTS_PASSWORD", "secret")
+
+	settings := config.HTTPSettings{
+		URLTemplate:    srv.URL + "/generic/{{ .Project }}/{{ .Tag }}/{{ .Name }}",
+		Headers:        map[string]string{"X-Foo": "bar"},
+		AuthType:       "basic",
+		UsernameEnv:    "ARTIFACTS_USER",
+		PasswordEnv:    "ARTIFACTS_PASSWORD",
+		ChecksumHeader: "X-Checksum-Sha256",
+	}
+	c.Assert(settings.Init(), qt.IsNil)
+	c.Assert(validateHTTP(settings), qt.IsNil)
+
+	client := newHTTPClient(settings)
+	info := ReleaseInfo{Project: "hugo", Tag: "v1.2.0"}
+
+	filename := filepath.Join(t.TempDir(), "hugo_1.2.0_linux-amd64.tar.gz")
+	c.Assert(os.WriteFile(filename, []byte("hello"), 0o644), qt.IsNil)
+
+	err := UploadAssetsFileWithRetries(context.Background(), client, info, httpReleaseID, func() (*os.File, error) {
+		return os.Open(filename)
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(numRequests, qt.Equals, 2)
+	c.Assert(uploaded, qt.DeepEquals, map[string]string{"PUT /generic/hugo/v1.2.0/hugo_1.2.0_linux-amd64.tar.gz": "hello"})
+	c.Assert(reqHeaders.Get("X-Foo"), qt.Equals, "bar")
+	c.Assert(reqHeaders.Get("Content-Type"), qt.Equals, "application/gzip")
+	c.Assert(reqHeaders.Get("X-Checksum-Sha256"), qt.Equals, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")
+
+	c.Setenv("ARTIFACTS_PASSWORD", "")
+	c.Assert(validateHTTP(settings), qt.ErrorMatches, `.*missing "ARTIFACTS_PASSWORD" env var`)
+}
+
+func TestHTTPClientReleaseNotesRetries(t *testing.T) {
+	c := qt.New(t)
+
+	var numRequests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests++
+		if numRequests == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		c.Check(r.URL.Path, qt.Equals, "/hugo/v1.2.0/release-notes.md")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	settings := config.HTTPSettings{URLTemplate: srv.URL + "/{{ .Project }}/{{ .Tag }}/{{ .Name }}"}
+	c.Assert(settings.Init(), qt.IsNil)
+
+	filename := filepath.Join(t.TempDir(), "notes.md")
+	c.Assert(os.WriteFile(filename, []byte("## Notes"), 0o644), qt.IsNil)
+
+	info := ReleaseInfo{Project: "hugo", Tag: "v1.2.0"}
+	info.Settings.ReleaseNotesSettings.Filename = filename
+	_, err := newHTTPClient(settings).Release(context.Background(), info)
+	c.Assert(err, qt.IsNil)
+	c.Assert(numRequests, qt.Equals, 2)
+}
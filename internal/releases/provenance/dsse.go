@@ -0,0 +1,124 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provenance
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Envelope is a DSSE envelope, see https://github.com/secure-systems-lab/dsse.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     []byte      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   []byte `json:"sig"`
+}
+
+// ParsePrivateKey parses a PEM encoded PKCS#1, PKCS#8, SEC 1 or OpenSSH private key.
+// Ed25519, ECDSA and RSA keys are supported.
+func ParsePrivateKey(key, passphrase []byte) (crypto.Signer, error) {
+	var (
+		k   any
+		err error
+	)
+	if len(passphrase) > 0 {
+		k, err = ssh.ParseRawPrivateKeyWithPassphrase(key, passphrase)
+	} else {
+		k, err = ssh.ParseRawPrivateKey(key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("provenance: failed to parse private key: %w", err)
+	}
+	switch k := k.(type) {
+	case *ed25519.PrivateKey:
+		return *k, nil
+	case crypto.Signer:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("provenance: unsupported private key type %T", k)
+	}
+}
+
+// Sign marshals the statement to JSON and wraps it in a DSSE envelope signed with signer.
+func Sign(statement Statement, signer crypto.Signer) (Envelope, error) {
+	if signer == nil {
+		// Never create an unsigned envelope.
+		return Envelope{}, errors.New("provenance: no private key to sign the envelope with")
+	}
+
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	pae := PAE(PayloadType, payload)
+
+	var sig []byte
+	switch signer.(type) {
+	case ed25519.PrivateKey:
+		sig, err = signer.Sign(rand.Reader, pae, crypto.Hash(0))
+	case *ecdsa.PrivateKey, *rsa.PrivateKey:
+		digest := sha256.Sum256(pae)
+		sig, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return Envelope{}, fmt.Errorf("provenance: unsupported private key type %T", signer)
+	}
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	keyID, err := KeyID(signer.Public())
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	return Envelope{
+		PayloadType: PayloadType,
+		Payload:     payload,
+		Signatures:  []Signature{{KeyID: keyID, Sig: sig}},
+	}, nil
+}
+
+// KeyID returns the hex encoded SHA256 of the PKIX encoded public key.
+func KeyID(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// PAE returns the DSSE pre-authentication encoding of the payload,
+// which is what gets signed.
+func PAE(payloadType string, payload []byte) []byte {
+	return fmt.Appendf(nil, "DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload)
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package provenance creates in-toto attestations with a SLSA provenance predicate
+// wrapped in a signed DSSE envelope.
+package provenance
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/gohugoio/hugoreleaser/internal/common/versionh"
+	"github.com/gohugoio/hugoreleaser/internal/config"
+	"github.com/gohugoio/hugoreleaser/internal/releases"
+)
+
+const (
+	StatementType  = "https://in-toto.io/Statement/v1"
+	PredicateType  = "https://slsa.dev/provenance/v1"
+	BuildType      = "https://github.com/gohugoio/hugoreleaser/release/v1"
+	PayloadType    = "application/vnd.in-toto+json"
+	DefaultBuilder = "https://github.com/gohugoio/hugoreleaser"
+)
+
+// Statement is an in-toto v1 statement.
+type Statement struct {
+	Type          string     `json:"_type"`
+	Subject       []Subject  `json:"subject"`
+	PredicateType string     `json:"predicateType"`
+	Predicate     Provenance `json:"predicate"`
+}
+
+// Subject is an artifact described by the statement.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Provenance is a SLSA v1 provenance predicate.
+type Provenance struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+type BuildDefinition struct {
+	BuildType            string               `json:"buildType"`
+	ExternalParameters   ExternalParameters   `json:"externalParameters"`
+	InternalParameters   InternalParameters   `json:"internalParameters"`
+	ResolvedDependencies []ResourceDescriptor `json:"resolvedDependencies,omitempty"`
+}
+
+type ExternalParameters struct {
+	Project   string             `json:"project"`
+	Tag       string             `json:"tag"`
+	Commitish string             `json:"commitish"`
+	Config    ResourceDescriptor `json:"config"`
+}
+
+type InternalParameters struct {
+	GoVersion string      `json:"goVersion"`
+	Builds    []BuildArch `json:"builds"`
+}
+
+// BuildArch holds the effective build settings used to build one archive.
+type BuildArch struct {
+	Path          string               `json:"path"`
+	Goos          string               `json:"goos"`
+	Goarch        string               `json:"goarch"`
+	BuildSettings config.BuildSettings `json:"buildSettings"`
+}
+
+type ResourceDescriptor struct {
+	Name   string            `json:"name,omitempty"`
+	URI    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// RunDetails describes the run. The optional metadata with the start and
+// finish times is left out, so the statement is the same when the release is run again.
+type RunDetails struct {
+	Builder Builder `json:"builder"`
+}
+
+type Builder struct {
+	ID      string            `json:"id"`
+	Version map[string]string `json:"version,omitempty"`
+}
+
+// Options holds the information used to create a Statement.
+type Options struct {
+	Project   string
+	Tag       string
+	Commitish string
+
+	// Defaults to DefaultBuilder.
+	BuilderID string
+
+	// The Hugoreleaser configuration file.
+	ConfigFilename string
+
+	// The output of go env GOVERSION.
+	GoVersion string
+
+	// The archives to use as subjects, with the SHA256 checksum set.
+	Archives []config.BuildArchPath
+}
+
+// NewStatement creates a new in-toto statement from opts.
+func NewStatement(opts Options) (Statement, error) {
+	configDigest, err := releases.SHA256File(opts.ConfigFilename)
+	if err != nil {
+		return Statement{}, fmt.Errorf("provenance: failed to create config file digest: %w", err)
+	}
+
+	builderID := opts.BuilderID
+	if builderID == "" {
+		builderID = DefaultBuilder
+	}
+
+	var (
+		subjects []Subject
+		builds   []BuildArch
+	)
+	for _, archive := range opts.Archives {
+		if archive.SHA256 == "" {
+			return Statement{}, fmt.Errorf("provenance: missing SHA256 checksum for %q", archive.Name)
+		}
+		for _, name := range append([]string{archive.Name}, archive.Aliases...) {
+			subjects = append(subjects, Subject{Name: name, Digest: map[string]string{"sha256": archive.SHA256}})
+		}
+		var goos string
+		if archive.Arch.Os != nil {
+			goos = archive.Arch.Os.Goos
+		}
+		builds = append(builds, BuildArch{
+			Path:          archive.Path,
+			Goos:          goos,
+			Goarch:        archive.Arch.Goarch,
+			BuildSettings: archive.Arch.BuildSettings,
+		})
+	}
+	sort.Slice(subjects, func(i, j int) bool { return subjects[i].Name < subjects[j].Name })
+
+	return Statement{
+		Type:          StatementType,
+		Subject:       subjects,
+		PredicateType: PredicateType,
+		Predicate: Provenance{
+			BuildDefinition: BuildDefinition{
+				BuildType: BuildType,
+				ExternalParameters: ExternalParameters{
+					Project:   opts.Project,
+					Tag:       opts.Tag,
+					Commitish: opts.Commitish,
+					Config: ResourceDescriptor{
+						Name:   filepath.Base(opts.ConfigFilename),
+						Digest: map[string]string{"sha256": configDigest},
+					},
+				},
+				InternalParameters: InternalParameters{
+					GoVersion: opts.GoVersion,
+					Builds:    builds,
+				},
+			},
+			RunDetails: RunDetails{
+				Builder: Builder{
+					ID:      builderID,
+					Version: map[string]string{"hugoreleaser": versionh.HugoreleaserVersion()},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provenance
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugoreleaser/internal/config"
+)
+
+func TestNewStatement(t *testing.T) {
+	c := qt.New(t)
+
+	configFilename := filepath.Join(t.TempDir(), "hugoreleaser.yaml")
+	c.Assert(os.WriteFile(configFilename, []byte("project: hugo\n"), 0o644), qt.IsNil)
+
+	statement, err := NewStatement(Options{
+		Project:        "hugo",
+		Tag:            "v1.2.0",
+		Commitish:      "main",
+		ConfigFilename: configFilename,
+		GoVersion:      "go1.24.0",
+		Archives: []config.BuildArchPath{
+			{
+				Name:    "hugo_1.2.0_linux-amd64.tar.gz",
+				Aliases: []string{"hugo_linux-amd64.tar.gz"},
+				Path:    "main/linux/amd64",
+				SHA256:  "abc",
+				Arch: config.BuildArch{
+					Goarch:        "amd64",
+					Os:            &config.BuildOs{Goos: "linux"},
+					BuildSettings: config.BuildSettings{Binary: "hugo", Flags: []string{"-trimpath"}},
+				},
+			},
+		},
+	})
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(statement.Type, qt.Equals, StatementType)
+	c.Assert(statement.PredicateType, qt.Equals, PredicateType)
+	c.Assert(statement.Subject, qt.DeepEquals, []Subject{
+		{Name: "hugo_1.2.0_linux-amd64.tar.gz", Digest: map[string]string{"sha256": "abc"}},
+		{Name: "hugo_linux-amd64.tar.gz", Digest: map[string]string{"sha256": "abc"}},
+	})
+
+	def := statement.Predicate.BuildDefinition
+	c.Assert(def.ExternalParameters.Tag, qt.Equals, "v1.2.0")
+	c.Assert(def.ExternalParameters.Commitish, qt.Equals, "main")
+	c.Assert(def.ExternalParameters.Config.Digest["sha256"], qt.Equals, sha256Hex("project: hugo\n"))
+	c.Assert(def.InternalParameters.GoVersion, qt.Equals, "go1.24.0")
+	c.Assert(def.InternalParameters.Builds, qt.DeepEquals, []BuildArch{
+		{Path: "main/linux/amd64", Goos: "linux", Goarch: "amd64", BuildSettings: config.BuildSettings{Binary: "hugo", Flags: []string{"-trimpath"}}},
+	})
+	c.Assert(statement.Predicate.RunDetails.Builder.ID, qt.Equals, DefaultBuilder)
+
+	_, err = NewStatement(Options{ConfigFilename: configFilename, Archives: []config.BuildArchPath{{Name: "foo.zip"}}})
+	c.Assert(err, qt.ErrorMatches, `provenance: missing SHA256 checksum for "foo.zip"`)
+}
+
+func TestSign(t *testing.T) {
+	c := qt.New(t)
+
+	statement := Statement{Type: StatementType, PredicateType: PredicateType}
+
+	c.Run("ed25519", func(c *qt.C) {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		c.Assert(err, qt.IsNil)
+		signer, err := ParsePrivateKey(pemEncode(c, priv), nil)
+		c.Assert(err, qt.IsNil)
+
+		envelope, err := Sign(statement, signer)
+		c.Assert(err, qt.IsNil)
+		c.Assert(envelope.PayloadType, qt.Equals, PayloadType)
+		c.Assert(envelope.Signatures, qt.HasLen, 1)
+		c.Assert(ed25519.Verify(pub, PAE(PayloadType, envelope.Payload), envelope.Signatures[0].Sig), qt.IsTrue)
+
+		var decoded Statement
+		c.Assert(json.Unmarshal(envelope.Payload, &decoded), qt.IsNil)
+		c.Assert(decoded.Type, qt.Equals, StatementType)
+
+		// The same statement gives the same envelope.
+		envelope2, err := Sign(statement, signer)
+		c.Assert(err, qt.IsNil)
+		c.Assert(envelope2, qt.DeepEquals, envelope)
+	})
+
+	c.Run("no key", func(c *qt.C) {
+		_, err := Sign(statement, nil)
+		c.Assert(err, qt.ErrorMatches, `provenance: no private key to sign the envelope with`)
+	})
+
+	c.Run("ecdsa", func(c *qt.C) {
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		c.Assert(err, qt.IsNil)
+		signer, err := ParsePrivateKey(pemEncode(c, priv), nil)
+		c.Assert(err, qt.IsNil)
+
+		envelope, err := Sign(statement, signer)
+		c.Assert(err, qt.IsNil)
+		digest := sha256.Sum256(PAE(PayloadType, envelope.Payload))
+		c.Assert(ecdsa.VerifyASN1(&priv.PublicKey, digest[:], envelope.Signatures[0].Sig), qt.IsTrue)
+
+		keyID, err := KeyID(&priv.PublicKey)
+		c.Assert(err, qt.IsNil)
+		c.Assert(envelope.Signatures[0].KeyID, qt.Equals, keyID)
+	})
+}
+
+func TestPAE(t *testing.T) {
+	c := qt.New(t)
+
+	// The example from the DSSE protocol spec.
+	c.Assert(string(PAE("http://example.com/HelloWorld", []byte("hello world"))), qt.Equals, "DSSEv1 29 http://example.com/HelloWorld 11 hello world")
+}
+
+func pemEncode(c *qt.C, key any) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	c.Assert(err, qt.IsNil)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func sha256Hex(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
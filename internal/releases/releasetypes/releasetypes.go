@@ -26,10 +26,16 @@ type Type int
 const (
 	InvalidType Type = iota
 	GitHub
+	FileSystem
+	S3
+	HTTP
 )
 
 var releaseTypeString = map[Type]string{
-	GitHub: "github",
+	GitHub:     "github",
+	FileSystem: "filesystem",
+	S3:         "s3",
+	HTTP:       "http",
 }
 
 var stringReleaseType = map[string]Type{}
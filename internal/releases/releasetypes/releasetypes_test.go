@@ -10,6 +10,9 @@ func TestType(t *testing.T) {
 	c := qt.New(t)
 
 	c.Assert(MustParse("Github"), qt.Equals, GitHub)
+	c.Assert(MustParse("filesystem"), qt.Equals, FileSystem)
+	c.Assert(MustParse("S3"), qt.Equals, S3)
+	c.Assert(MustParse("http"), qt.Equals, HTTP)
 
 	_, err := Parse("invalid")
 	c.Assert(err, qt.ErrorMatches, "invalid release type \"invalid\", must be one of .*")
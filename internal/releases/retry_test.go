@@ -0,0 +1,74 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugoreleaser/internal/config"
+	"github.com/google/go-github/v45/github"
+)
+
+func TestTemporaryError(t *testing.T) {
+	c := qt.New(t)
+
+	err := fmt.Errorf("upload: %w", TemporaryError{errors.New("connection reset")})
+	c.Assert(errors.Is(err, TemporaryError{}), qt.IsTrue)
+	c.Assert(errors.Is(errors.New("connection reset"), TemporaryError{}), qt.IsFalse)
+	c.Assert(err, qt.ErrorMatches, "upload: connection reset")
+}
+
+func TestUploadAssetsFileWithRetriesGitHub(t *testing.T) {
+	c := qt.New(t)
+
+	var numRequests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests++
+		switch numRequests {
+		case 1:
+			// A temporary error, retried.
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 1}`)
+		}
+	}))
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	u, err := url.Parse(srv.URL + "/")
+	c.Assert(err, qt.IsNil)
+	gh.BaseURL, gh.UploadURL = u, u
+	client := &GitHubClient{client: gh}
+
+	filename := filepath.Join(t.TempDir(), "hugo.tar.gz")
+	c.Assert(os.WriteFile(filename, []byte("hugo"), 0o644), qt.IsNil)
+
+	info := ReleaseInfo{Tag: "v1.2.0", Settings: config.ReleaseSettings{RepositoryOwner: "bep", Repository: "hugo"}}
+	err = UploadAssetsFileWithRetries(context.Background(), client, info, 42, func() (*os.File, error) {
+		return os.Open(filename)
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(numRequests, qt.Equals, 2)
+}
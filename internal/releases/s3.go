@@ -0,0 +1,600 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releases
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gohugoio/hugoreleaser/internal/common/semver"
+	"github.com/gohugoio/hugoreleaser/internal/common/templ"
+	"github.com/gohugoio/hugoreleaser/internal/config"
+)
+
+const (
+	s3AccessKeyIDEnvVar     = "AWS_ACCESS_KEY_ID"
+	s3SecretAccessKeyEnvVar = "AWS_SECRET_ACCESS_KEY"
+	s3SessionTokenEnvVar    = "AWS_SESSION_TOKEN"
+	s3RegionEnvVar          = "AWS_REGION"
+)
+
+// S3 has no concept of a release, so we use the same ID for all of them.
+const s3ReleaseID = 1
+
+func validateS3() error {
+	for _, k := range []string{s3AccessKeyIDEnvVar, s3SecretAccessKeyEnvVar} {
+		if os.Getenv(k) == "" {
+			return fmt.Errorf("release: missing %q env var", k)
+		}
+	}
+	return nil
+}
+
+func newS3Client(settings config.S3Settings) (*S3Client, error) {
+	region := settings.Region
+	if region == "" {
+		region = os.Getenv(s3RegionEnvVar)
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	var (
+		endpoint  *url.URL
+		pathStyle bool
+		err       error
+	)
+	if settings.Endpoint != "" {
+		endpoint, err = url.Parse(settings.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("s3: invalid endpoint %q: %w", settings.Endpoint, err)
+		}
+		pathStyle = true
+	} else {
+		endpoint = &url.URL{Scheme: "https", Host: fmt.Sprintf("%s.s3.%s.amazonaws.com", settings.Bucket, region)}
+	}
+
+	return &S3Client{
+		httpClient: http.DefaultClient,
+		settings:   settings,
+		endpoint:   endpoint,
+		pathStyle:  pathStyle,
+		signer: s3Signer{
+			accessKeyID:     os.Getenv(s3AccessKeyIDEnvVar),
+			secretAccessKey: os.Getenv(s3SecretAccessKeyEnvVar),
+			sessionToken:    os.Getenv(s3SessionTokenEnvVar),
+			region:          region,
+			now:             time.Now,
+		},
+	}, nil
+}
+
+// S3Client uploads release artifacts to an S3 compatible object storage.
+type S3Client struct {
+	httpClient *http.Client
+	settings   config.S3Settings
+	endpoint   *url.URL
+	pathStyle  bool
+	signer     s3Signer
+
+	mu sync.Mutex
+	// The keys uploaded by this client, used in DeleteRelease.
+	uploaded []string
+	// The names of the files uploaded for this release, copied to the latest prefix in FinalizeRelease.
+	names []string
+}
+
+// Release uploads the release notes, if any. There's no release to create in S3.
+func (c *S3Client) Release(ctx context.Context, info ReleaseInfo) (int64, error) {
+	// Validate the latest prefix before anything is uploaded.
+	if _, err := c.latestKeyPrefix(info); err != nil {
+		return 0, err
+	}
+	if filename := info.Settings.ReleaseNotesSettings.Filename; filename != "" {
+		f, err := os.Open(filename)
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+		if err := c.upload(ctx, info, f, "release-notes.md"); err != nil {
+			return 0, err
+		}
+	}
+	return s3ReleaseID, nil
+}
+
+func (c *S3Client) UploadAssetsFile(ctx context.Context, info ReleaseInfo, f *os.File, releaseID int64) error {
+	return c.upload(ctx, info, f, filepath.Base(f.Name()))
+}
+
+func (c *S3Client) upload(ctx context.Context, info ReleaseInfo, f *os.File, name string) error {
+	prefix, err := c.keyPrefix(c.settings.KeyPrefixTemplate, info)
+	if err != nil {
+		return err
+	}
+	key := path.Join(prefix, name)
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	headers := http.Header{}
+	headers.Set("Content-Type", contentType(name))
+	if c.settings.CacheControl != "" {
+		headers.Set("Cache-Control", c.settings.CacheControl)
+	}
+
+	if fi.Size() > c.settings.PartSize {
+		err = c.putObjectMultipart(ctx, key, f, fi.Size(), headers)
+	} else {
+		err = c.putObject(ctx, key, f, fi.Size(), headers)
+	}
+	if err != nil {
+		return err
+	}
+	c.addUploaded(key)
+
+	c.mu.Lock()
+	c.names = append(c.names, name)
+	c.mu.Unlock()
+
+	return nil
+}
+
+// FinalizeRelease copies the uploaded files to the latest prefix, if set, and deletes
+// any other objects below it, e.g. files from the previous release with the version in their name.
+// This is done once all files are uploaded, so a failed upload leaves the latest prefix as it was.
+func (c *S3Client) FinalizeRelease(ctx context.Context, info ReleaseInfo, releaseID int64) error {
+	latestPrefix, err := c.latestKeyPrefix(info)
+	if err != nil {
+		return err
+	}
+	if latestPrefix == "" {
+		return nil
+	}
+	prefix, err := c.keyPrefix(c.settings.KeyPrefixTemplate, info)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	names := append([]string(nil), c.names...)
+	c.mu.Unlock()
+	sort.Strings(names)
+
+	latestKeys := make(map[string]bool)
+	for _, name := range names {
+		key, latestKey := path.Join(prefix, name), path.Join(latestPrefix, name)
+		headers := http.Header{}
+		headers.Set("Content-Type", contentType(name))
+		headers.Set("Cache-Control", c.settings.LatestCacheControl)
+		if err := c.copyObject(ctx, key, latestKey, headers); err != nil {
+			return err
+		}
+		c.addUploaded(latestKey)
+		latestKeys[latestKey] = true
+	}
+
+	keys, err := c.listObjects(ctx, latestPrefix+"/")
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if latestKeys[key] {
+			continue
+		}
+		if err := c.deleteObject(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// latestKeyPrefix returns the latest prefix for info, or an empty string if not set.
+// The objects below it not part of the release are deleted in FinalizeRelease,
+// so it must not be the bucket root or contain the key prefix.
+func (c *S3Client) latestKeyPrefix(info ReleaseInfo) (string, error) {
+	if c.settings.LatestKeyPrefixTemplate == "" {
+		return "", nil
+	}
+	latestPrefix, err := c.keyPrefix(c.settings.LatestKeyPrefixTemplate, info)
+	if err != nil {
+		return "", err
+	}
+	if latestPrefix == "" {
+		return "", fmt.Errorf("s3: latest_key_prefix_template must not resolve to the bucket root")
+	}
+	prefix, err := c.keyPrefix(c.settings.KeyPrefixTemplate, info)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(prefix+"/", latestPrefix+"/") {
+		return "", fmt.Errorf("s3: key prefix %q must not be below the latest key prefix %q", prefix, latestPrefix)
+	}
+	return latestPrefix, nil
+}
+
+// DeleteRelease deletes the objects uploaded by this client, including any copies in the latest prefix.
+// The objects replaced or deleted in the latest prefix are not restored.
+func (c *S3Client) DeleteRelease(ctx context.Context, info ReleaseInfo, releaseID int64) error {
+	c.mu.Lock()
+	keys := c.uploaded
+	c.uploaded = nil
+	c.mu.Unlock()
+
+	for _, key := range keys {
+		if err := c.deleteObject(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *S3Client) addUploaded(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.uploaded = append(c.uploaded, key)
+}
+
+func (c *S3Client) keyPrefix(tmpl string, info ReleaseInfo) (string, error) {
+	prefix, err := templ.Sprintt(tmpl, struct {
+		Project string
+		Tag     string
+		semver.Version
+	}{
+		Project: info.Project,
+		Tag:     info.Tag,
+		Version: info.Version,
+	})
+	if err != nil {
+		return "", fmt.Errorf("s3: failed to execute key prefix template: %w", err)
+	}
+	return strings.Trim(prefix, "/"), nil
+}
+
+func (c *S3Client) putObject(ctx context.Context, key string, r io.ReadSeeker, size int64, headers http.Header) error {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	req, err := c.newRequest(ctx, http.MethodPut, key, nil, io.NopCloser(r), size, headers)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(req, nil)
+	return err
+}
+
+func (c *S3Client) putObjectMultipart(ctx context.Context, key string, r io.ReaderAt, size int64, headers http.Header) (err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil, 0, headers)
+	if err != nil {
+		return err
+	}
+	var initResult struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if _, err := c.do(req, &initResult); err != nil {
+		return err
+	}
+
+	uploadID := initResult.UploadID
+
+	defer func() {
+		if err == nil {
+			return
+		}
+		// Abort the upload so we don't leave orphaned parts behind.
+		if req, aerr := c.newRequest(context.Background(), http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil, 0, nil); aerr == nil {
+			c.do(req, nil)
+		}
+	}()
+
+	type completedPart struct {
+		PartNumber int
+		ETag       string
+	}
+
+	var parts []completedPart
+	partSize := c.settings.PartSize
+
+	for offset, partNumber := int64(0), 1; offset < size; offset, partNumber = offset+partSize, partNumber+1 {
+		n := min(partSize, size-offset)
+		query := url.Values{
+			"partNumber": {strconv.Itoa(partNumber)},
+			"uploadId":   {uploadID},
+		}
+		req, err := c.newRequest(ctx, http.MethodPut, key, query, io.NopCloser(io.NewSectionReader(r, offset, n)), n, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := c.do(req, nil)
+		if err != nil {
+			return err
+		}
+		parts = append(parts, completedPart{PartNumber: partNumber, ETag: resp.Header.Get("ETag")})
+	}
+
+	complete := struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{
+		Parts: parts,
+	}
+	b, err := xml.Marshal(complete)
+	if err != nil {
+		return err
+	}
+
+	req, err = c.newRequest(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, io.NopCloser(bytes.NewReader(b)), int64(len(b)), nil)
+	if err != nil {
+		return err
+	}
+
+	// S3 may return an error in the body of a 200 OK response.
+	var completeResult struct {
+		XMLName xml.Name
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if _, err := c.do(req, &completeResult); err != nil {
+		return err
+	}
+	if completeResult.XMLName.Local == "Error" {
+		return TemporaryError{fmt.Errorf("s3: failed to complete multipart upload of %q: %s: %s", key, completeResult.Code, completeResult.Message)}
+	}
+
+	return nil
+}
+
+func (c *S3Client) copyObject(ctx context.Context, fromKey, toKey string, headers http.Header) error {
+	headers = headers.Clone()
+	headers.Set("X-Amz-Copy-Source", "/"+c.settings.Bucket+"/"+s3EscapePath(fromKey))
+	headers.Set("X-Amz-Metadata-Directive", "REPLACE")
+	req, err := c.newRequest(ctx, http.MethodPut, toKey, nil, nil, 0, headers)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(req, nil)
+	return err
+}
+
+func (c *S3Client) deleteObject(ctx context.Context, key string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, key, nil, nil, 0, nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(req, nil)
+	return err
+}
+
+// listObjects lists the keys of the objects below prefix.
+func (c *S3Client) listObjects(ctx context.Context, prefix string) ([]string, error) {
+	var (
+		keys              []string
+		continuationToken string
+	)
+	for {
+		query := url.Values{
+			"list-type": {"2"},
+			"prefix":    {prefix},
+		}
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+		req, err := c.newRequest(ctx, http.MethodGet, "", query, nil, 0, nil)
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			Contents []struct {
+				Key string `xml:"Key"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		if _, err := c.do(req, &result); err != nil {
+			return nil, err
+		}
+		for _, content := range result.Contents {
+			keys = append(keys, content.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+func (c *S3Client) newRequest(ctx context.Context, method, key string, query url.Values, body io.ReadCloser, size int64, headers http.Header) (*http.Request, error) {
+	u := *c.endpoint
+	if c.pathStyle {
+		u.Path = path.Join("/", u.Path, c.settings.Bucket, key)
+	} else {
+		u.Path = "/" + key
+	}
+	u.RawPath = s3EscapePath(u.Path)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	for k, v := range headers {
+		req.Header[k] = v
+	}
+	c.signer.sign(req)
+	return req, nil
+}
+
+func (c *S3Client) do(req *http.Request, v any) (*http.Response, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, TemporaryError{err}
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, TemporaryError{err}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		err := fmt.Errorf("s3: %s %s: unexpected status code %d: %s", req.Method, req.URL.Path, resp.StatusCode, b)
+		if isTemporaryHttpStatus(resp.StatusCode) && resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusNotFound {
+			return nil, TemporaryError{err}
+		}
+		return nil, err
+	}
+
+	if v != nil && len(b) > 0 {
+		if err := xml.Unmarshal(b, v); err != nil {
+			return nil, fmt.Errorf("s3: failed to decode response: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// s3EscapePath escapes p according to the S3 rules, leaving the slashes as is.
+func s3EscapePath(p string) string {
+	parts := strings.Split(p, "/")
+	for i, part := range parts {
+		parts[i] = s3Escape(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+// s3Escape escapes s as specified by RFC 3986, which is what AWS Signature Version 4 expects.
+func s3Escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// s3Signer signs requests using AWS Signature Version 4.
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+type s3Signer struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+	region          string
+	now             func() time.Time
+}
+
+const s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+func (s s3Signer) sign(req *http.Request) {
+	const service = "s3"
+
+	t := s.now().UTC()
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+
+	if req.Header.Get("X-Amz-Content-Sha256") == "" {
+		req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+	}
+	req.Header.Set("X-Amz-Date", amzDate)
+	if s.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+
+	headers := map[string]string{
+		"host": req.URL.Host,
+	}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.Join(v, ",")
+	}
+	headerNames := make([]string, 0, len(headers))
+	for k := range headers {
+		headerNames = append(headerNames, k)
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, k := range headerNames {
+		canonicalHeaders.WriteString(k + ":" + strings.TrimSpace(headers[k]) + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+
+	scope := strings.Join([]string{date, s.region, service, "aws4_request"}, "/")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	key := s3HMAC([]byte("AWS4"+s.secretAccessKey), date)
+	key = s3HMAC(key, s.region)
+	key = s3HMAC(key, service)
+	key = s3HMAC(key, "aws4_request")
+	signature := hex.EncodeToString(s3HMAC(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, s3Escape(k)+"="+s3Escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func s3HMAC(key []byte, s string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(s))
+	return h.Sum(nil)
+}
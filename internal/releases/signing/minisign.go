@@ -0,0 +1,159 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/scrypt"
+)
+
+// See https://jedisct1.github.io/minisign/ for the file formats.
+const (
+	minisignKeyLen   = 8 + ed25519.PrivateKeySize + blake2b.Size256
+	minisignKeyFile  = 2 + 2 + 2 + 32 + 8 + 8 + minisignKeyLen
+	minisignHashedEd = "ED"
+)
+
+// minisignSigner creates prehashed minisign signatures.
+type minisignSigner struct {
+	keyID      [8]byte
+	privateKey ed25519.PrivateKey
+
+	// The timestamp in the trusted comment.
+	signedAt time.Time
+}
+
+func newMinisignSigner(key, passphrase []byte, signedAt time.Time) (*minisignSigner, error) {
+	b, err := decodeMinisignFile(key)
+	if err != nil {
+		return nil, fmt.Errorf("signing: failed to decode minisign secret key: %w", err)
+	}
+	if len(b) != minisignKeyFile {
+		return nil, errors.New("signing: invalid minisign secret key length")
+	}
+
+	sigAlg, kdfAlg, cksumAlg := b[0:2], string(b[2:4]), string(b[4:6])
+	salt := b[6:38]
+	opsLimit := binary.LittleEndian.Uint64(b[38:46])
+	memLimit := binary.LittleEndian.Uint64(b[46:54])
+	keyNum := bytes.Clone(b[54:])
+
+	if string(sigAlg) != "Ed" || cksumAlg != "B2" {
+		return nil, errors.New("signing: unsupported minisign secret key algorithm")
+	}
+
+	switch kdfAlg {
+	case "Sc":
+		if len(passphrase) == 0 {
+			return nil, errors.New("signing: the minisign secret key is encrypted, but no passphrase was provided")
+		}
+		n, r, p := scryptParams(opsLimit, memLimit)
+		stream, err := scrypt.Key(passphrase, salt, n, r, p, minisignKeyLen)
+		if err != nil {
+			return nil, err
+		}
+		subtle.XORBytes(keyNum, keyNum, stream)
+	case "\x00\x00":
+	default:
+		return nil, errors.New("signing: unsupported minisign key derivation algorithm")
+	}
+
+	keyID, secretKey, checksum := keyNum[:8], keyNum[8:8+ed25519.PrivateKeySize], keyNum[8+ed25519.PrivateKeySize:]
+	h, _ := blake2b.New256(nil)
+	h.Write(sigAlg)
+	h.Write(keyID)
+	h.Write(secretKey)
+	if subtle.ConstantTimeCompare(h.Sum(nil), checksum) != 1 {
+		return nil, errors.New("signing: invalid minisign secret key checksum, wrong passphrase?")
+	}
+
+	s := &minisignSigner{privateKey: ed25519.PrivateKey(secretKey), signedAt: signedAt}
+	copy(s.keyID[:], keyID)
+	return s, nil
+}
+
+func (s *minisignSigner) Extension() string {
+	return ".minisig"
+}
+
+func (s *minisignSigner) Sign(r io.Reader, name string) ([]byte, error) {
+	h, _ := blake2b.New512(nil)
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, err
+	}
+
+	signature := ed25519.Sign(s.privateKey, h.Sum(nil))
+	sigLine := append(append([]byte(minisignHashedEd), s.keyID[:]...), signature...)
+
+	trustedComment := fmt.Sprintf("timestamp:%d\tfile:%s\thashed", s.signedAt.Unix(), name)
+	globalSignature := ed25519.Sign(s.privateKey, append(bytes.Clone(signature), trustedComment...))
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "untrusted comment: signature from minisign secret key\n")
+	fmt.Fprintf(&buf, "%s\n", base64.StdEncoding.EncodeToString(sigLine))
+	fmt.Fprintf(&buf, "trusted comment: %s\n", trustedComment)
+	fmt.Fprintf(&buf, "%s\n", base64.StdEncoding.EncodeToString(globalSignature))
+	return buf.Bytes(), nil
+}
+
+// decodeMinisignFile decodes the base64 line following the untrusted comment.
+func decodeMinisignFile(b []byte) ([]byte, error) {
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) < 2 || !strings.HasPrefix(lines[0], "untrusted comment:") {
+		return nil, errors.New("missing untrusted comment")
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+}
+
+// scryptParams derives the scrypt parameters from the libsodium limits stored in the key file.
+// This is a port of pickparams in libsodium's crypto_pwhash_scryptsalsa208sha256.
+func scryptParams(opsLimit, memLimit uint64) (n, r, p int) {
+	if opsLimit < 32768 {
+		opsLimit = 32768
+	}
+	r = 8
+	var maxN uint64
+	if opsLimit < memLimit/32 {
+		p = 1
+		maxN = opsLimit / uint64(r*4)
+	} else {
+		maxN = memLimit / uint64(r*128)
+	}
+	nLog2 := 1
+	for ; nLog2 < 63; nLog2++ {
+		if uint64(1)<<nLog2 > maxN/2 {
+			break
+		}
+	}
+	if p == 0 {
+		maxRP := (opsLimit / 4) / (uint64(1) << nLog2)
+		if maxRP > 0x3fffffff {
+			maxRP = 0x3fffffff
+		}
+		p = int(maxRP) / r
+	}
+	return 1 << nLog2, r, p
+}
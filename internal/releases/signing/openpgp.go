@@ -0,0 +1,99 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+// openPGPSigner creates armored OpenPGP detached signatures.
+type openPGPSigner struct {
+	entity *openpgp.Entity
+	config *packet.Config
+}
+
+func newOpenPGPSigner(key, passphrase []byte, signedAt time.Time) (*openPGPSigner, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(key))
+	if err != nil {
+		return nil, fmt.Errorf("signing: failed to read OpenPGP key: %w", err)
+	}
+	if len(entities) != 1 {
+		return nil, fmt.Errorf("signing: expected 1 OpenPGP key, got %d", len(entities))
+	}
+	entity := entities[0]
+	if entity.PrivateKey == nil {
+		return nil, errors.New("signing: the OpenPGP key is not a private key")
+	}
+
+	if entity.PrivateKey.Encrypted {
+		if len(passphrase) == 0 {
+			return nil, errors.New("signing: the OpenPGP key is encrypted, but no passphrase was provided")
+		}
+		if err := entity.DecryptPrivateKeys(passphrase); err != nil {
+			return nil, fmt.Errorf("signing: failed to decrypt OpenPGP key: %w", err)
+		}
+	}
+
+	// A signature created before the key or its self-signatures is rejected,
+	// e.g. for a key created after the commit the signing time is derived from.
+	notBefore := entity.PrimaryKey.CreationTime
+	for _, identity := range entity.Identities {
+		if identity.SelfSignature != nil && identity.SelfSignature.CreationTime.After(notBefore) {
+			notBefore = identity.SelfSignature.CreationTime
+		}
+	}
+	for _, subkey := range entity.Subkeys {
+		for _, t := range []time.Time{subkey.PublicKey.CreationTime, subkey.Sig.CreationTime} {
+			if t.After(notBefore) {
+				notBefore = t
+			}
+		}
+	}
+	if signedAt.Before(notBefore) {
+		signedAt = notBefore
+	}
+
+	// Like GnuPG, don't add a random salt notation, so signatures made with
+	// RSA and EdDSA keys are deterministic.
+	randomize := false
+
+	return &openPGPSigner{
+		entity: entity,
+		config: &packet.Config{
+			Time:                                  func() time.Time { return signedAt },
+			NonDeterministicSignaturesViaNotation: &randomize,
+		},
+	}, nil
+}
+
+func (s *openPGPSigner) Extension() string {
+	return ".asc"
+}
+
+func (s *openPGPSigner) Sign(r io.Reader, name string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&buf, s.entity, r, s.config); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
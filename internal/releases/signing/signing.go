@@ -0,0 +1,101 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package signing creates detached signatures for release artifacts.
+package signing
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gohugoio/hugoreleaser/internal/config"
+)
+
+// Signer creates detached signatures.
+type Signer interface {
+	// Sign returns the detached signature of the content read from r.
+	// The name is the base filename of the signed file.
+	Sign(r io.Reader, name string) ([]byte, error)
+
+	// Extension returns the file extension of the signature files, e.g. ".sig".
+	Extension() string
+}
+
+// New creates a new Signer for the given settings, reading the private key from
+// the key file or the key env var and the passphrase, if any, from the passphrase env var.
+// The signatures are marked as created at signedAt, where supported, so signing the same
+// content again produces the same signature for the deterministic key types (e.g. Ed25519).
+func New(settings config.SigningSettings, signedAt time.Time) (Signer, error) {
+	key, passphrase, err := ReadKey(settings.KeyFile, settings.KeyEnv, settings.PassphraseEnv)
+	if err != nil {
+		return nil, fmt.Errorf("signing: %w", err)
+	}
+
+	switch settings.Type {
+	case "ssh":
+		return newSSHSigner(key, passphrase, settings.Namespace)
+	case "minisign":
+		return newMinisignSigner(key, passphrase, signedAt)
+	case "openpgp":
+		return newOpenPGPSigner(key, passphrase, signedAt)
+	default:
+		return nil, fmt.Errorf("signing: unsupported type %q", settings.Type)
+	}
+}
+
+// ReadKey reads the private key from keyFile or, if not set, from the keyEnv env var,
+// and the passphrase, if any, from the passphraseEnv env var.
+func ReadKey(keyFile, keyEnv, passphraseEnv string) (key, passphrase []byte, err error) {
+	if keyFile != "" {
+		key, err = os.ReadFile(keyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read key file: %w", err)
+		}
+	} else {
+		key = []byte(os.Getenv(keyEnv))
+		if len(key) == 0 {
+			return nil, nil, fmt.Errorf("missing %q env var", keyEnv)
+		}
+	}
+	if passphraseEnv != "" {
+		passphrase = []byte(os.Getenv(passphraseEnv))
+	}
+	return key, passphrase, nil
+}
+
+// SignFile signs filename and writes the signature to a file in dir
+// named after filename with the signer's extension appended.
+// It returns the signature filename.
+func SignFile(signer Signer, filename, dir string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	name := filepath.Base(filename)
+	sig, err := signer.Sign(f, name)
+	if err != nil {
+		return "", fmt.Errorf("signing: failed to sign %q: %w", name, err)
+	}
+
+	sigFilename := filepath.Join(dir, name+signer.Extension())
+	if err := os.WriteFile(sigFilename, sig, 0o644); err != nil {
+		return "", err
+	}
+	return sigFilename, nil
+}
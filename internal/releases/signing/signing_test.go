@@ -0,0 +1,257 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugoreleaser/internal/config"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/ssh"
+)
+
+const content = "hello checksums\n"
+
+var signedAt = time.Unix(1700000000, 0)
+
+func TestSSHSigner(t *testing.T) {
+	c := qt.New(t)
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	c.Assert(err, qt.IsNil)
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(priv, "", []byte("secret"))
+	c.Assert(err, qt.IsNil)
+
+	c.Setenv("SIGNING_KEY", string(pem.EncodeToMemory(block)))
+	c.Setenv("SIGNING_PASSPHRASE", "secret")
+
+	settings := config.SigningSettings{Type: "ssh", KeyEnv: "SIGNING_KEY", PassphraseEnv: "SIGNING_PASSPHRASE"}
+	c.Assert(settings.Init(), qt.IsNil)
+	signer, err := New(settings, signedAt)
+	c.Assert(err, qt.IsNil)
+	c.Assert(signer.Extension(), qt.Equals, ".sig")
+
+	sig, err := signer.Sign(strings.NewReader(content), "checksums.txt")
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(sig), qt.Matches, `(?s)-----BEGIN SSH SIGNATURE-----\n.*\n-----END SSH SIGNATURE-----\n`)
+
+	// Verify the signature.
+	lines := strings.Split(strings.TrimSpace(string(sig)), "\n")
+	blob, err := base64.StdEncoding.DecodeString(strings.Join(lines[1:len(lines)-1], ""))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(blob[:6]), qt.Equals, "SSHSIG")
+
+	var parsed struct {
+		Version       uint32
+		PublicKey     string
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     string
+	}
+	c.Assert(ssh.Unmarshal(blob[6:], &parsed), qt.IsNil)
+	c.Assert(parsed.Namespace, qt.Equals, "file")
+	c.Assert(parsed.HashAlgorithm, qt.Equals, "sha512")
+
+	publicKey, err := ssh.ParsePublicKey([]byte(parsed.PublicKey))
+	c.Assert(err, qt.IsNil)
+	sshPub, err := ssh.NewPublicKey(pub)
+	c.Assert(err, qt.IsNil)
+	c.Assert(publicKey.Marshal(), qt.DeepEquals, sshPub.Marshal())
+
+	var signature ssh.Signature
+	c.Assert(ssh.Unmarshal([]byte(parsed.Signature), &signature), qt.IsNil)
+	h := sha512.Sum512([]byte(content))
+	signedData := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace, Reserved, HashAlgorithm, Hash string
+	}{"file", "", "sha512", string(h[:])})...)
+	c.Assert(publicKey.Verify(signedData, &signature), qt.IsNil)
+
+	// Wrong passphrase.
+	c.Setenv("SIGNING_PASSPHRASE", "wrong")
+	_, err = New(settings, signedAt)
+	c.Assert(err, qt.ErrorMatches, "signing: failed to parse SSH private key.*")
+}
+
+func TestMinisignSigner(t *testing.T) {
+	c := qt.New(t)
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	c.Assert(err, qt.IsNil)
+	keyID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+	keyFilename := filepath.Join(t.TempDir(), "minisign.key")
+	c.Assert(os.WriteFile(keyFilename, newMinisignKeyFile(c, keyID, priv, []byte("secret")), 0o600), qt.IsNil)
+	c.Setenv("SIGNING_PASSPHRASE", "secret")
+
+	settings := config.SigningSettings{Type: "minisign", KeyFile: keyFilename, PassphraseEnv: "SIGNING_PASSPHRASE"}
+	c.Assert(settings.Init(), qt.IsNil)
+	signer, err := New(settings, signedAt)
+	c.Assert(err, qt.IsNil)
+	c.Assert(signer.Extension(), qt.Equals, ".minisig")
+
+	sig, err := signer.Sign(strings.NewReader(content), "checksums.txt")
+	c.Assert(err, qt.IsNil)
+
+	lines := strings.Split(strings.TrimSpace(string(sig)), "\n")
+	c.Assert(lines, qt.HasLen, 4)
+	c.Assert(lines[0], qt.Equals, "untrusted comment: signature from minisign secret key")
+	c.Assert(lines[2], qt.Equals, "trusted comment: timestamp:1700000000\tfile:checksums.txt\thashed")
+
+	sig2, err := signer.Sign(strings.NewReader(content), "checksums.txt")
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(sig2), qt.Equals, string(sig))
+
+	sigLine, err := base64.StdEncoding.DecodeString(lines[1])
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(sigLine[:2]), qt.Equals, "ED")
+	c.Assert(sigLine[2:10], qt.DeepEquals, keyID)
+	h := blake2b.Sum512([]byte(content))
+	c.Assert(ed25519.Verify(pub, h[:], sigLine[10:]), qt.IsTrue)
+
+	globalSig, err := base64.StdEncoding.DecodeString(lines[3])
+	c.Assert(err, qt.IsNil)
+	c.Assert(ed25519.Verify(pub, append(sigLine[10:], "timestamp:1700000000\tfile:checksums.txt\thashed"...), globalSig), qt.IsTrue)
+
+	// Wrong passphrase.
+	c.Setenv("SIGNING_PASSPHRASE", "wrong")
+	_, err = New(settings, signedAt)
+	c.Assert(err, qt.ErrorMatches, ".*checksum, wrong passphrase.*")
+}
+
+func TestScryptParams(t *testing.T) {
+	c := qt.New(t)
+
+	// The minisign defaults.
+	n, r, p := scryptParams(33554432, 1073741824)
+	c.Assert([]int{n, r, p}, qt.DeepEquals, []int{1 << 20, 8, 1})
+
+	n, r, p = scryptParams(32768, 16<<20)
+	c.Assert([]int{n, r, p}, qt.DeepEquals, []int{1 << 10, 8, 1})
+}
+
+func TestOpenPGPSigner(t *testing.T) {
+	c := qt.New(t)
+
+	entity, err := openpgp.NewEntity("Hugoreleaser", "", "hugoreleaser@example.org", nil)
+	c.Assert(err, qt.IsNil)
+
+	var publicKey bytes.Buffer
+	w, err := armor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(entity.Serialize(w), qt.IsNil)
+	c.Assert(w.Close(), qt.IsNil)
+
+	c.Assert(entity.EncryptPrivateKeys([]byte("secret"), nil), qt.IsNil)
+	var privateKey bytes.Buffer
+	w, err = armor.Encode(&privateKey, openpgp.PrivateKeyType, nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(entity.SerializePrivateWithoutSigning(w, nil), qt.IsNil)
+	c.Assert(w.Close(), qt.IsNil)
+
+	c.Setenv("SIGNING_KEY", privateKey.String())
+	c.Setenv("SIGNING_PASSPHRASE", "secret")
+
+	settings := config.SigningSettings{Type: "openpgp", KeyEnv: "SIGNING_KEY", PassphraseEnv: "SIGNING_PASSPHRASE"}
+	signer, err := New(settings, signedAt)
+	c.Assert(err, qt.IsNil)
+	c.Assert(signer.Extension(), qt.Equals, ".asc")
+
+	sig, err := signer.Sign(strings.NewReader(content), "checksums.txt")
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(sig), qt.Matches, `(?s)-----BEGIN PGP SIGNATURE-----\n.*-----END PGP SIGNATURE-----\n`)
+
+	// The key is created after signedAt, so the key creation time is used.
+	sig2, err := signer.Sign(strings.NewReader(content), "checksums.txt")
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(sig2), qt.Equals, string(sig))
+
+	keyRing, err := openpgp.ReadArmoredKeyRing(&publicKey)
+	c.Assert(err, qt.IsNil)
+	_, err = openpgp.CheckArmoredDetachedSignature(keyRing, strings.NewReader(content), bytes.NewReader(sig), nil)
+	c.Assert(err, qt.IsNil)
+	_, err = openpgp.CheckArmoredDetachedSignature(keyRing, strings.NewReader("tampered"), bytes.NewReader(sig), nil)
+	c.Assert(err, qt.Not(qt.IsNil))
+}
+
+func TestSignFile(t *testing.T) {
+	c := qt.New(t)
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	c.Assert(err, qt.IsNil)
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	c.Assert(err, qt.IsNil)
+
+	tempDir := t.TempDir()
+	keyFilename := filepath.Join(tempDir, "id_ed25519")
+	c.Assert(os.WriteFile(keyFilename, pem.EncodeToMemory(block), 0o600), qt.IsNil)
+	filename := filepath.Join(tempDir, "checksums.txt")
+	c.Assert(os.WriteFile(filename, []byte(content), 0o644), qt.IsNil)
+
+	signer, err := New(config.SigningSettings{Type: "ssh", KeyFile: keyFilename}, signedAt)
+	c.Assert(err, qt.IsNil)
+
+	outDir := filepath.Join(tempDir, "out")
+	c.Assert(os.Mkdir(outDir, 0o755), qt.IsNil)
+	sigFilename, err := SignFile(signer, filename, outDir)
+	c.Assert(err, qt.IsNil)
+	c.Assert(sigFilename, qt.Equals, filepath.Join(outDir, "checksums.txt.sig"))
+	_, err = os.Stat(sigFilename)
+	c.Assert(err, qt.IsNil)
+}
+
+// newMinisignKeyFile creates an encrypted minisign secret key file using cheap scrypt parameters.
+func newMinisignKeyFile(c *qt.C, keyID []byte, priv ed25519.PrivateKey, passphrase []byte) []byte {
+	const opsLimit, memLimit = 32768, 16 << 20
+
+	h, _ := blake2b.New256(nil)
+	h.Write([]byte("Ed"))
+	h.Write(keyID)
+	h.Write(priv)
+	keyNum := append(append(append([]byte{}, keyID...), priv...), h.Sum(nil)...)
+
+	salt := make([]byte, 32)
+	_, err := rand.Read(salt)
+	c.Assert(err, qt.IsNil)
+	n, r, p := scryptParams(opsLimit, memLimit)
+	stream, err := scrypt.Key(passphrase, salt, n, r, p, len(keyNum))
+	c.Assert(err, qt.IsNil)
+	for i := range keyNum {
+		keyNum[i] ^= stream[i]
+	}
+
+	b := []byte("EdScB2")
+	b = append(b, salt...)
+	b = binary.LittleEndian.AppendUint64(b, opsLimit)
+	b = binary.LittleEndian.AppendUint64(b, memLimit)
+	b = append(b, keyNum...)
+
+	return []byte("untrusted comment: minisign encrypted secret key\n" + base64.StdEncoding.EncodeToString(b) + "\n")
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// See https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+const (
+	sshSigMagic         = "SSHSIG"
+	sshSigVersion       = 1
+	sshSigHashAlgorithm = "sha512"
+)
+
+// sshSigner creates signatures compatible with ssh-keygen -Y sign.
+type sshSigner struct {
+	signer    ssh.Signer
+	namespace string
+}
+
+func newSSHSigner(key, passphrase []byte, namespace string) (*sshSigner, error) {
+	var (
+		signer ssh.Signer
+		err    error
+	)
+	if len(passphrase) > 0 {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(key, passphrase)
+	} else {
+		signer, err = ssh.ParsePrivateKey(key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("signing: failed to parse SSH private key: %w", err)
+	}
+	if namespace == "" {
+		namespace = "file"
+	}
+	return &sshSigner{signer: signer, namespace: namespace}, nil
+}
+
+func (s *sshSigner) Extension() string {
+	return ".sig"
+}
+
+func (s *sshSigner) Sign(r io.Reader, name string) ([]byte, error) {
+	h := sha512.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, err
+	}
+
+	signedData := struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          string
+	}{
+		Namespace:     s.namespace,
+		HashAlgorithm: sshSigHashAlgorithm,
+		Hash:          string(h.Sum(nil)),
+	}
+	// The magic preamble is not length prefixed.
+	message := append([]byte(sshSigMagic), ssh.Marshal(signedData)...)
+
+	var (
+		sig *ssh.Signature
+		err error
+	)
+	if s.signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		// ssh-keygen refuses the SHA-1 based ssh-rsa signatures.
+		algorithmSigner, ok := s.signer.(ssh.AlgorithmSigner)
+		if !ok {
+			return nil, fmt.Errorf("RSA key does not support rsa-sha2-512 signatures")
+		}
+		sig, err = algorithmSigner.SignWithAlgorithm(rand.Reader, message, ssh.KeyAlgoRSASHA512)
+	} else {
+		sig, err = s.signer.Sign(rand.Reader, message)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	blob := struct {
+		Version       uint32
+		PublicKey     string
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     string
+	}{
+		Version:       sshSigVersion,
+		PublicKey:     string(s.signer.PublicKey().Marshal()),
+		Namespace:     s.namespace,
+		HashAlgorithm: sshSigHashAlgorithm,
+		Signature:     string(ssh.Marshal(sig)),
+	}
+
+	return armorSSH("SSH SIGNATURE", append([]byte(sshSigMagic), ssh.Marshal(blob)...), 70), nil
+}
+
+// armorSSH encodes b as base64 wrapped at width columns between BEGIN and END lines of the given type.
+func armorSSH(typ string, b []byte, width int) []byte {
+	enc := base64.StdEncoding.EncodeToString(b)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "-----BEGIN %s-----\n", typ)
+	for len(enc) > width {
+		buf.WriteString(enc[:width] + "\n")
+		enc = enc[width:]
+	}
+	buf.WriteString(enc + "\n")
+	fmt.Fprintf(&buf, "-----END %s-----\n", typ)
+	return buf.Bytes()
+}
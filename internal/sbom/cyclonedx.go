@@ -0,0 +1,136 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gohugoio/hugoreleaser/internal/common/versionh"
+)
+
+// See https://cyclonedx.org/docs/1.5/json/
+type cdxBOM struct {
+	BOMFormat    string          `json:"bomFormat"`
+	SpecVersion  string          `json:"specVersion"`
+	SerialNumber string          `json:"serialNumber,omitempty"`
+	Version      int             `json:"version"`
+	Metadata     cdxMetadata     `json:"metadata"`
+	Components   []cdxComponent  `json:"components"`
+	Dependencies []cdxDependency `json:"dependencies"`
+}
+
+type cdxMetadata struct {
+	Timestamp time.Time    `json:"timestamp"`
+	Tools     cdxTools     `json:"tools"`
+	Component cdxComponent `json:"component"`
+}
+
+type cdxTools struct {
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	BOMRef     string        `json:"bom-ref,omitempty"`
+	Type       string        `json:"type"`
+	Name       string        `json:"name"`
+	Version    string        `json:"version,omitempty"`
+	PURL       string        `json:"purl,omitempty"`
+	Hashes     []cdxHash     `json:"hashes,omitempty"`
+	Properties []cdxProperty `json:"properties,omitempty"`
+}
+
+type cdxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+func (d *document) cycloneDX() ([]byte, error) {
+	projectRef := d.Project + "@" + d.Tag
+
+	bom := cdxBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+		Metadata: cdxMetadata{
+			Timestamp: d.Created,
+			Tools: cdxTools{
+				Components: []cdxComponent{{Type: "application", Name: "hugoreleaser", Version: versionh.HugoreleaserVersion()}},
+			},
+			Component: cdxComponent{BOMRef: projectRef, Type: "application", Name: d.Project, Version: d.Tag},
+		},
+	}
+
+	projectDeps := cdxDependency{Ref: projectRef, DependsOn: []string{}}
+
+	for _, b := range d.Binaries {
+		ref := b.Goos + "/" + b.Goarch + "/" + b.Name
+		bom.Components = append(bom.Components, cdxComponent{
+			BOMRef:  ref,
+			Type:    "application",
+			Name:    b.Name,
+			Version: d.Tag,
+			PURL:    b.Main.PURL(),
+			Properties: []cdxProperty{
+				{Name: "go:goos", Value: b.Goos},
+				{Name: "go:goarch", Value: b.Goarch},
+				{Name: "go:version", Value: b.GoVersion},
+				{Name: "go:main_module", Value: b.Main.ID()},
+			},
+		})
+		projectDeps.DependsOn = append(projectDeps.DependsOn, ref)
+
+		binDeps := cdxDependency{Ref: ref, DependsOn: []string{}}
+		for _, m := range b.Deps {
+			binDeps.DependsOn = append(binDeps.DependsOn, m.PURL())
+		}
+		bom.Dependencies = append(bom.Dependencies, binDeps)
+	}
+
+	for _, m := range d.Modules {
+		c := cdxComponent{
+			BOMRef:  m.PURL(),
+			Type:    "library",
+			Name:    m.Path,
+			Version: m.Version,
+			PURL:    m.PURL(),
+		}
+		if m.SHA256 != "" {
+			c.Hashes = []cdxHash{{Alg: "SHA-256", Content: m.SHA256}}
+		}
+		bom.Components = append(bom.Components, c)
+	}
+
+	bom.Dependencies = append([]cdxDependency{projectDeps}, bom.Dependencies...)
+
+	// Create a reproducible serial number from the content.
+	b, err := json.Marshal(bom)
+	if err != nil {
+		return nil, err
+	}
+	bom.SerialNumber = "urn:uuid:" + uuid(b)
+
+	return json.MarshalIndent(bom, "", "  ")
+}
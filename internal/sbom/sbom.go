@@ -0,0 +1,220 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sbom creates software bills of materials from the build info embedded in Go binaries.
+package sbom
+
+import (
+	"crypto/sha256"
+	"debug/buildinfo"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	CycloneDX = "cyclonedx"
+	SPDX      = "spdx"
+)
+
+// Formats is the list of supported SBOM formats.
+var Formats = []string{CycloneDX, SPDX}
+
+// Extension returns the file extension to use for the given format.
+func Extension(format string) string {
+	switch format {
+	case CycloneDX:
+		return ".cdx.json"
+	case SPDX:
+		return ".spdx.json"
+	default:
+		panic(fmt.Sprintf("unknown SBOM format %q", format))
+	}
+}
+
+// Binary is a Go binary to describe in the SBOM.
+type Binary struct {
+	// The binary name, e.g. "hugo".
+	Name   string
+	Goos   string
+	Goarch string
+
+	// The binary file to read the build info from.
+	Filename string
+}
+
+// Options describes what to put in the SBOM.
+type Options struct {
+	// The project name and the release tag, used to name the document.
+	Project string
+	Tag     string
+
+	Binaries []Binary
+}
+
+// Generate creates an SBOM in the given format, see Formats.
+func Generate(format string, opts Options) ([]byte, error) {
+	doc, err := newDocument(opts)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case CycloneDX:
+		return doc.cycloneDX()
+	case SPDX:
+		return doc.spdx()
+	default:
+		return nil, fmt.Errorf("sbom: unsupported format %q, must be one of %v", format, Formats)
+	}
+}
+
+// document is the format independent SBOM.
+type document struct {
+	Project string
+	Tag     string
+
+	// Set from the VCS time in the build info, if available, else from the binary's modification time,
+	// so the SBOM is reproducible.
+	Created time.Time
+
+	Binaries []binary
+
+	// All modules used by the binaries, sorted by path and version.
+	Modules []module
+}
+
+type binary struct {
+	Binary
+	Main      module
+	GoVersion string
+	Deps      []module
+}
+
+type module struct {
+	Path    string
+	Version string
+
+	// The hex encoded SHA-256 of the go.sum h1: hash, if any.
+	SHA256 string
+}
+
+func (m module) ID() string {
+	return m.Path + "@" + m.Version
+}
+
+// PURL returns the package URL of the module, see https://github.com/package-url/purl-spec.
+func (m module) PURL() string {
+	purl := "pkg:golang/" + m.Path
+	if m.Version != "" {
+		purl += "@" + strings.ReplaceAll(m.Version, "+", "%2B")
+	}
+	return purl
+}
+
+func newDocument(opts Options) (*document, error) {
+	doc := &document{
+		Project: opts.Project,
+		Tag:     opts.Tag,
+	}
+
+	modules := make(map[string]module)
+
+	for _, b := range opts.Binaries {
+		bi, err := buildinfo.ReadFile(b.Filename)
+		if err != nil {
+			return nil, fmt.Errorf("sbom: failed to read build info from %q: %w", b.Filename, err)
+		}
+
+		created := vcsTime(bi)
+		if created.IsZero() {
+			fi, err := os.Stat(b.Filename)
+			if err != nil {
+				return nil, err
+			}
+			created = fi.ModTime()
+		}
+		if created.After(doc.Created) {
+			doc.Created = created
+		}
+
+		bin := binary{
+			Binary:    b,
+			Main:      newModule(&bi.Main),
+			GoVersion: bi.GoVersion,
+		}
+
+		// Include the standard library, which is what the vulnerability scanners look for.
+		stdlib := module{Path: "stdlib", Version: bi.GoVersion}
+		bin.Deps = append(bin.Deps, stdlib)
+
+		for _, dep := range bi.Deps {
+			bin.Deps = append(bin.Deps, newModule(dep))
+		}
+
+		for _, m := range bin.Deps {
+			modules[m.ID()] = m
+		}
+
+		doc.Binaries = append(doc.Binaries, bin)
+	}
+
+	for _, m := range modules {
+		doc.Modules = append(doc.Modules, m)
+	}
+	sort.Slice(doc.Modules, func(i, j int) bool { return doc.Modules[i].ID() < doc.Modules[j].ID() })
+	sort.Slice(doc.Binaries, func(i, j int) bool {
+		return doc.Binaries[i].Goos+doc.Binaries[i].Goarch+doc.Binaries[i].Name < doc.Binaries[j].Goos+doc.Binaries[j].Goarch+doc.Binaries[j].Name
+	})
+
+	doc.Created = doc.Created.UTC().Truncate(time.Second)
+
+	return doc, nil
+}
+
+func newModule(m *debug.Module) module {
+	if m.Replace != nil {
+		m = m.Replace
+	}
+	mod := module{Path: m.Path, Version: m.Version}
+	if h, ok := strings.CutPrefix(m.Sum, "h1:"); ok {
+		if b, err := base64.StdEncoding.DecodeString(h); err == nil {
+			mod.SHA256 = hex.EncodeToString(b)
+		}
+	}
+	return mod
+}
+
+func vcsTime(bi *buildinfo.BuildInfo) time.Time {
+	for _, s := range bi.Settings {
+		if s.Key == "vcs.time" {
+			t, _ := time.Parse(time.RFC3339, s.Value)
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// uuid creates a deterministic UUID from the given content,
+// used to get reproducible serial numbers and namespaces.
+func uuid(content []byte) string {
+	h := sha256.Sum256(content)
+	h[6] = (h[6] & 0x0f) | 0x50 // Version 5 style.
+	h[8] = (h[8] & 0x3f) | 0x80 // RFC 4122 variant.
+	return fmt.Sprintf("%x-%x-%x-%x-%x", h[0:4], h[4:6], h[6:8], h[8:10], h[10:16])
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func testOptions(c *qt.C) Options {
+	// The test binary is a Go binary with build info.
+	exe, err := os.Executable()
+	c.Assert(err, qt.IsNil)
+	return Options{
+		Project: "hugo",
+		Tag:     "v1.2.0",
+		Binaries: []Binary{
+			{Name: "hugo", Goos: runtime.GOOS, Goarch: runtime.GOARCH, Filename: exe},
+		},
+	}
+}
+
+func TestCycloneDX(t *testing.T) {
+	c := qt.New(t)
+
+	opts := testOptions(c)
+	b, err := Generate(CycloneDX, opts)
+	c.Assert(err, qt.IsNil)
+
+	var bom cdxBOM
+	c.Assert(json.Unmarshal(b, &bom), qt.IsNil)
+	c.Assert(bom.BOMFormat, qt.Equals, "CycloneDX")
+	c.Assert(bom.SpecVersion, qt.Equals, "1.5")
+	c.Assert(bom.SerialNumber, qt.Matches, `urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}`)
+	c.Assert(bom.Metadata.Component.Name, qt.Equals, "hugo")
+	c.Assert(bom.Metadata.Component.Version, qt.Equals, "v1.2.0")
+
+	components := make(map[string]cdxComponent)
+	for _, comp := range bom.Components {
+		components[comp.Name] = comp
+	}
+	c.Assert(components["hugo"].Type, qt.Equals, "application")
+	c.Assert(components["stdlib"].PURL, qt.Equals, "pkg:golang/stdlib@"+runtime.Version())
+	quicktest := components["github.com/frankban/quicktest"]
+	c.Assert(quicktest.Type, qt.Equals, "library")
+	c.Assert(quicktest.PURL, qt.Matches, `pkg:golang/github.com/frankban/quicktest@v1\..*`)
+	c.Assert(quicktest.Hashes, qt.HasLen, 1)
+	c.Assert(quicktest.Hashes[0].Content, qt.Matches, `[0-9a-f]{64}`)
+
+	c.Assert(bom.Dependencies[0].Ref, qt.Equals, "hugo@v1.2.0")
+	c.Assert(bom.Dependencies[0].DependsOn, qt.DeepEquals, []string{runtime.GOOS + "/" + runtime.GOARCH + "/hugo"})
+	c.Assert(bom.Dependencies[1].DependsOn, qt.Contains, quicktest.PURL)
+
+	// The output is reproducible.
+	b2, err := Generate(CycloneDX, opts)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b2), qt.Equals, string(b))
+}
+
+func TestSPDX(t *testing.T) {
+	c := qt.New(t)
+
+	b, err := Generate(SPDX, testOptions(c))
+	c.Assert(err, qt.IsNil)
+
+	var doc spdxDocument
+	c.Assert(json.Unmarshal(b, &doc), qt.IsNil)
+	c.Assert(doc.SPDXVersion, qt.Equals, "SPDX-2.3")
+	c.Assert(doc.Name, qt.Equals, "hugo-v1.2.0")
+	c.Assert(doc.DocumentNamespace, qt.Matches, `https://github.com/gohugoio/hugoreleaser/spdx/hugo-v1.2.0-.*`)
+	c.Assert(doc.Relationships[0], qt.DeepEquals, spdxRelationship{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: "SPDXRef-Project-hugo"})
+
+	var found bool
+	for _, p := range doc.Packages {
+		c.Assert(p.SPDXID, qt.Matches, `SPDXRef-[a-zA-Z0-9.-]+`)
+		if p.Name == "github.com/frankban/quicktest" {
+			found = true
+			c.Assert(p.PrimaryPackagePurpose, qt.Equals, "LIBRARY")
+			c.Assert(p.ExternalRefs[0].ReferenceLocator, qt.Equals, "pkg:golang/github.com/frankban/quicktest@"+p.VersionInfo)
+			c.Assert(p.Checksums[0].Algorithm, qt.Equals, "SHA256")
+		}
+	}
+	c.Assert(found, qt.IsTrue)
+}
+
+func TestGenerateErrors(t *testing.T) {
+	c := qt.New(t)
+
+	filename := filepath.Join(t.TempDir(), "hugo")
+	c.Assert(os.WriteFile(filename, []byte("not a binary"), 0o755), qt.IsNil)
+
+	_, err := Generate(CycloneDX, Options{Binaries: []Binary{{Name: "hugo", Filename: filename}}})
+	c.Assert(err, qt.ErrorMatches, `sbom: failed to read build info from .*`)
+
+	_, err = Generate("swid", testOptions(c))
+	c.Assert(err, qt.ErrorMatches, `sbom: unsupported format "swid".*`)
+}
+
+func TestPURL(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(module{Path: "github.com/foo/bar", Version: "v2.0.0+incompatible"}.PURL(), qt.Equals, "pkg:golang/github.com/foo/bar@v2.0.0%2Bincompatible")
+	c.Assert(strings.HasPrefix(uuid([]byte("foo")), uuid([]byte("foo"))[:8]), qt.IsTrue)
+}
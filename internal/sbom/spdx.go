@@ -0,0 +1,145 @@
+// Copyright 2026 The Hugoreleaser Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"regexp"
+	"time"
+
+	"github.com/gohugoio/hugoreleaser/internal/common/versionh"
+)
+
+const spdxNoAssertion = "NOASSERTION"
+
+// See https://spdx.github.io/spdx-spec/v2.3/
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  time.Time `json:"created"`
+	Creators []string  `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID                string            `json:"SPDXID"`
+	Name                  string            `json:"name"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	LicenseConcluded      string            `json:"licenseConcluded"`
+	LicenseDeclared       string            `json:"licenseDeclared"`
+	CopyrightText         string            `json:"copyrightText"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose"`
+	Checksums             []spdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs          []spdxExternalRef `json:"externalRefs,omitempty"`
+	Comment               string            `json:"comment,omitempty"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+var spdxIDInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+func spdxID(s string) string {
+	return "SPDXRef-" + spdxIDInvalidChars.ReplaceAllString(s, "-")
+}
+
+func (d *document) spdx() ([]byte, error) {
+	projectID := spdxID("Project-" + d.Project)
+
+	doc := spdxDocument{
+		SPDXVersion: "SPDX-2.3",
+		DataLicense: "CC0-1.0",
+		SPDXID:      "SPDXRef-DOCUMENT",
+		Name:        d.Project + "-" + d.Tag,
+		CreationInfo: spdxCreationInfo{
+			Created:  d.Created,
+			Creators: []string{"Tool: hugoreleaser-" + versionh.HugoreleaserVersion()},
+		},
+		Packages: []spdxPackage{newSPDXPackage(projectID, d.Project, d.Tag, "APPLICATION")},
+		Relationships: []spdxRelationship{
+			{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: projectID},
+		},
+	}
+
+	moduleID := func(m module) string {
+		return spdxID("Module-" + m.ID())
+	}
+
+	for _, b := range d.Binaries {
+		id := spdxID("Binary-" + b.Goos + "-" + b.Goarch + "-" + b.Name)
+		p := newSPDXPackage(id, b.Name, d.Tag, "APPLICATION")
+		p.Comment = "GOOS=" + b.Goos + " GOARCH=" + b.Goarch + " " + b.GoVersion + " main module " + b.Main.ID()
+		doc.Packages = append(doc.Packages, p)
+		doc.Relationships = append(doc.Relationships, spdxRelationship{SPDXElementID: projectID, RelationshipType: "CONTAINS", RelatedSPDXElement: id})
+		for _, m := range b.Deps {
+			doc.Relationships = append(doc.Relationships, spdxRelationship{SPDXElementID: id, RelationshipType: "DEPENDS_ON", RelatedSPDXElement: moduleID(m)})
+		}
+	}
+
+	for _, m := range d.Modules {
+		p := newSPDXPackage(moduleID(m), m.Path, m.Version, "LIBRARY")
+		p.ExternalRefs = []spdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: m.PURL()}}
+		if m.SHA256 != "" {
+			p.Checksums = []spdxChecksum{{Algorithm: "SHA256", ChecksumValue: m.SHA256}}
+		}
+		doc.Packages = append(doc.Packages, p)
+	}
+
+	// Create a reproducible namespace from the content.
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	doc.DocumentNamespace = "https://github.com/gohugoio/hugoreleaser/spdx/" + doc.Name + "-" + uuid(b)
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func newSPDXPackage(id, name, version, purpose string) spdxPackage {
+	return spdxPackage{
+		SPDXID:                id,
+		Name:                  name,
+		VersionInfo:           version,
+		DownloadLocation:      spdxNoAssertion,
+		LicenseConcluded:      spdxNoAssertion,
+		LicenseDeclared:       spdxNoAssertion,
+		CopyrightText:         spdxNoAssertion,
+		PrimaryPackagePurpose: purpose,
+	}
+}
@@ -14,14 +14,21 @@ var (
 	//go:embed templates/homebrew-cask.rb.gotmpl
 	homebrewCaskTemplContent []byte
 
+	//go:embed templates/homebrew-formula.rb.gotmpl
+	homebrewFormulaTemplContent []byte
+
 	// ReleaseNotesTemplate is the template for the release notes.
 	ReleaseNotesTemplate *template.Template
 
 	// HomebrewCaskTemplate is the template for the Homebrew cask file.
 	HomebrewCaskTemplate *template.Template
+
+	// HomebrewFormulaTemplate is the template for the Homebrew formula file.
+	HomebrewFormulaTemplate *template.Template
 )
 
 func init() {
 	ReleaseNotesTemplate = template.Must(template.New("release-notes").Funcs(templ.BuiltInFuncs).Parse(string(releaseNotesTemplContent)))
 	HomebrewCaskTemplate = template.Must(template.New("homebrew-cask").Funcs(templ.BuiltInFuncs).Parse(string(homebrewCaskTemplContent)))
+	HomebrewFormulaTemplate = template.Must(template.New("homebrew-formula").Funcs(templ.BuiltInFuncs).Parse(string(homebrewFormulaTemplContent)))
 }